@@ -0,0 +1,182 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Client is the shared http client for the DBT cloud api. It is created by the provider
+// and passed to all resources as meta. Requests have no fixed timeout, they are cancelled
+// through the context passed by terraform, which carries the resource timeouts.
+type Client struct {
+	HostUrl      string
+	AccountId    int
+	ServiceToken string
+	MaxRetries   int
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+	httpClient   *http.Client
+}
+
+// Response is the envelope DBT wraps every api response in.
+type Response[T any] struct {
+	Data T `json:"data"`
+}
+
+func NewClient(hostUrl string, serviceToken string, accountId int) *Client {
+	return &Client{
+		HostUrl:      hostUrl,
+		AccountId:    accountId,
+		ServiceToken: serviceToken,
+		MaxRetries:   4,
+		RetryWaitMin: 1 * time.Second,
+		RetryWaitMax: 30 * time.Second,
+		httpClient:   &http.Client{},
+	}
+}
+
+// Do sends a request to path (relative to HostUrl), with requestBody encoded as json if it is not nil.
+// The response body is returned for 2xx responses, all other status codes are returned as an *Error.
+// Throttled and temporarily failing requests are retried up to MaxRetries times.
+func (c *Client) Do(ctx context.Context, method string, path string, requestBody interface{}) ([]byte, error) {
+	url := c.HostUrl + path
+
+	var body []byte
+	if requestBody != nil {
+		var err error
+		body, err = json.Marshal(requestBody)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		response, data, err := c.send(ctx, method, url, body)
+
+		if attempt < c.MaxRetries && shouldRetry(ctx, method, response, err) {
+			wait := c.retryWait(attempt, response)
+
+			fields := map[string]interface{}{
+				"method":  method,
+				"url":     url,
+				"attempt": attempt + 1,
+				"wait":    wait.String(),
+			}
+			if err != nil {
+				fields["error"] = err.Error()
+			} else {
+				fields["status_code"] = response.StatusCode
+			}
+			tflog.Warn(ctx, "Retrying DBT cloud api request", fields)
+
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(wait):
+			}
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if response.StatusCode < 200 || response.StatusCode > 299 {
+			return nil, &Error{
+				Method:     method,
+				Url:        url,
+				StatusCode: response.StatusCode,
+				Message:    string(data),
+			}
+		}
+
+		return data, nil
+	}
+}
+
+func (c *Client) send(ctx context.Context, method string, url string, body []byte) (*http.Response, []byte, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.ServiceToken))
+
+	start := time.Now()
+	response, err := c.httpClient.Do(req)
+	if err != nil {
+		c.logRequest(ctx, req, body, nil, nil, err, time.Since(start))
+		return nil, nil, err
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(response.Body)
+	c.logRequest(ctx, req, body, response, data, err, time.Since(start))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response, data, nil
+}
+
+// Get fetches path and decodes the data field of the response into T.
+func Get[T any](ctx context.Context, c *Client, path string) (*T, error) {
+	data, err := c.Do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return decode[T](data)
+}
+
+// Post sends requestBody as json to path and decodes the data field of the response into T.
+func Post[T any](ctx context.Context, c *Client, path string, requestBody interface{}) (*T, error) {
+	data, err := c.Do(ctx, http.MethodPost, path, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	return decode[T](data)
+}
+
+// Put sends requestBody as json to path and decodes the data field of the response into T.
+func Put[T any](ctx context.Context, c *Client, path string, requestBody interface{}) (*T, error) {
+	data, err := c.Do(ctx, http.MethodPut, path, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	return decode[T](data)
+}
+
+func (c *Client) Delete(ctx context.Context, path string) error {
+	_, err := c.Do(ctx, http.MethodDelete, path, nil)
+
+	return err
+}
+
+func decode[T any](data []byte) (*T, error) {
+	var response Response[T]
+	err := json.Unmarshal(data, &response)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse DBT response as json: %w", err)
+	}
+
+	return &response.Data, nil
+}
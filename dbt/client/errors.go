@@ -0,0 +1,25 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Error is returned by the Client when DBT responds with a non 2xx status code.
+type Error struct {
+	Method     string
+	Url        string
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("DBT returned StatusCode %d for %s (%s), message: %s", e.StatusCode, e.Method, e.Url, e.Message)
+}
+
+func IsNotFound(err error) bool {
+	var apiError *Error
+
+	return errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const redacted = "***REDACTED***"
+
+// sensitiveKeys are substrings of json field names whose values are never written to the log.
+var sensitiveKeys = []string{
+	"token",
+	"secret",
+	"password",
+	"private_key",
+	"passphrase",
+	"authorization",
+	"api_key",
+}
+
+// secretEnvironmentVariablePrefix marks DBT environment variables with a secret value. Their values are sent in fields
+// like environment_values and raw_value, so objects are redacted by their name instead of by their field names.
+const secretEnvironmentVariablePrefix = "DBT_ENV_SECRET_"
+
+// secretEnvironmentVariableKeys are the fields holding the value of an environment variable.
+var secretEnvironmentVariableKeys = []string{
+	"environment_values",
+	"raw_value",
+	"value",
+	"display_value",
+}
+
+// logRequest writes the request and its outcome to the terraform log. Bodies are only logged at TRACE level.
+func (c *Client) logRequest(ctx context.Context, req *http.Request, requestBody []byte, response *http.Response, responseBody []byte, err error, duration time.Duration) {
+	ctx = tflog.MaskAllFieldValuesStrings(ctx, c.ServiceToken)
+
+	fields := map[string]interface{}{
+		"method":      req.Method,
+		"url":         req.URL.String(),
+		"duration_ms": duration.Milliseconds(),
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "DBT cloud api request failed", fields)
+		return
+	}
+
+	fields["status_code"] = response.StatusCode
+	tflog.Debug(ctx, "DBT cloud api request", fields)
+
+	fields["request_headers"] = redactHeaders(req.Header)
+	fields["request_body"] = redactBody(requestBody)
+	fields["response_body"] = redactBody(responseBody)
+	tflog.Trace(ctx, "DBT cloud api request and response bodies", fields)
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for key := range header {
+		if isSensitiveKey(key) {
+			headers[key] = redacted
+		} else {
+			headers[key] = header.Get(key)
+		}
+	}
+
+	return headers
+}
+
+// redactBody replaces the values of sensitive fields in a json body. Bodies that are not json are
+// logged as they are, since DBT only returns json and plain text error messages.
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return string(body)
+	}
+
+	redactedBody, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return redacted
+	}
+
+	return string(redactedBody)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		secretEnvironmentVariable := isSecretEnvironmentVariable(v)
+		for key, item := range v {
+			if item != nil && (isSensitiveKey(key) || secretEnvironmentVariable && isSecretEnvironmentVariableKey(key)) {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+
+	return value
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitiveKey := range sensitiveKeys {
+		if strings.Contains(key, sensitiveKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSecretEnvironmentVariable(value map[string]interface{}) bool {
+	name, ok := value["name"].(string)
+	return ok && strings.HasPrefix(strings.ToUpper(name), secretEnvironmentVariablePrefix)
+}
+
+func isSecretEnvironmentVariableKey(key string) bool {
+	for _, secretKey := range secretEnvironmentVariableKeys {
+		if key == secretKey {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{
+			name:     "empty body",
+			body:     "",
+			expected: "",
+		},
+		{
+			name:     "plain text is kept",
+			body:     "Internal Server Error",
+			expected: "Internal Server Error",
+		},
+		{
+			name:     "no sensitive fields",
+			body:     `{"id":1,"name":"analytics"}`,
+			expected: `{"id":1,"name":"analytics"}`,
+		},
+		{
+			name:     "top level fields",
+			body:     `{"password":"hunter2","private_key":"-----BEGIN","user":"dbt"}`,
+			expected: `{"password":"***REDACTED***","private_key":"***REDACTED***","user":"dbt"}`,
+		},
+		{
+			name:     "field names are matched case insensitive and by substring",
+			body:     `{"HMAC_Secret":"abc","oauth_client_secret":"def","token_uri":"https://oauth2.googleapis.com/token"}`,
+			expected: `{"HMAC_Secret":"***REDACTED***","oauth_client_secret":"***REDACTED***","token_uri":"***REDACTED***"}`,
+		},
+		{
+			name:     "nested objects",
+			body:     `{"data":{"details":{"account":"ab123","private_key_passphrase":"xyz"}}}`,
+			expected: `{"data":{"details":{"account":"ab123","private_key_passphrase":"***REDACTED***"}}}`,
+		},
+		{
+			name:     "objects in arrays",
+			body:     `{"data":[{"name":"a","api_key":"1"},{"name":"b","api_key":"2"}]}`,
+			expected: `{"data":[{"api_key":"***REDACTED***","name":"a"},{"api_key":"***REDACTED***","name":"b"}]}`,
+		},
+		{
+			name:     "sensitive objects are replaced completely",
+			body:     `{"credential_details":{"fields":{"token":{"value":"dapi123"}}}}`,
+			expected: `{"credential_details":{"fields":{"token":"***REDACTED***"}}}`,
+		},
+		{
+			name:     "values of secret environment variables",
+			body:     `{"name":"DBT_ENV_SECRET_GIT_TOKEN","environment_values":{"project":"ghp_123","Production":"ghp_456"}}`,
+			expected: `{"environment_values":"***REDACTED***","name":"DBT_ENV_SECRET_GIT_TOKEN"}`,
+		},
+		{
+			name:     "job overrides of secret environment variables",
+			body:     `{"data":{"id":7,"name":"DBT_ENV_SECRET_GIT_TOKEN","raw_value":"ghp_789","display_value":"****"}}`,
+			expected: `{"data":{"display_value":"***REDACTED***","id":7,"name":"DBT_ENV_SECRET_GIT_TOKEN","raw_value":"***REDACTED***"}}`,
+		},
+		{
+			name:     "values of other environment variables are kept",
+			body:     `{"name":"DBT_TARGET_SCHEMA","environment_values":{"project":"analytics"}}`,
+			expected: `{"environment_values":{"project":"analytics"},"name":"DBT_TARGET_SCHEMA"}`,
+		},
+		{
+			name:     "environment variables keyed by secret names",
+			body:     `{"data":{"DBT_ENV_SECRET_GIT_TOKEN":{"project":{"id":1,"value":"ghp_123"}}}}`,
+			expected: `{"data":{"DBT_ENV_SECRET_GIT_TOKEN":"***REDACTED***"}}`,
+		},
+		{
+			name:     "null values are kept",
+			body:     `{"password":null}`,
+			expected: `{"password":null}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := redactBody([]byte(test.body)); actual != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	headers := redactHeaders(http.Header{
+		"Authorization": {"Bearer abc"},
+		"Content-Type":  {"application/json"},
+	})
+
+	if headers["Authorization"] != redacted {
+		t.Errorf("expected the Authorization header to be redacted, got %s", headers["Authorization"])
+	}
+
+	if headers["Content-Type"] != "application/json" {
+		t.Errorf("expected the Content-Type header to be kept, got %s", headers["Content-Type"])
+	}
+}
+
+func TestLogRequestDoesNotLogSecrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"id":1,"hmac_secret":"response-secret"}}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	c := NewClient(server.URL, "service-token", 1)
+	_, err := c.Do(ctx, http.MethodPost, "/", map[string]interface{}{"password": "request-secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.Do(ctx, http.MethodPut, "/", map[string]interface{}{
+		"name":               "DBT_ENV_SECRET_GIT_TOKEN",
+		"environment_values": map[string]string{"project": "environment-secret"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(output.String(), "DBT cloud api request and response bodies") {
+		t.Fatalf("expected the bodies to be logged at TRACE level, got %s", output.String())
+	}
+
+	for _, secret := range []string{"service-token", "request-secret", "response-secret", "environment-secret"} {
+		if strings.Contains(output.String(), secret) {
+			t.Errorf("expected %q to be redacted from the log, got %s", secret, output.String())
+		}
+	}
+}
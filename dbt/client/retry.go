@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// shouldRetry reports whether a request should be sent again. Throttled requests are always retried
+// since DBT did not process them, other failures are only retried for idempotent methods.
+func shouldRetry(ctx context.Context, method string, response *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return isIdempotent(method) && isConnectionReset(err)
+	}
+
+	switch response.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	}
+
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+func isConnectionReset(err error) bool {
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// retryWait returns how long to wait before the given retry attempt (starting at 0). A Retry-After
+// header from DBT takes precedence over the exponential backoff.
+func (c *Client) retryWait(attempt int, response *http.Response) time.Duration {
+	if response != nil {
+		if wait, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+			return wait
+		}
+	}
+
+	wait := float64(c.RetryWaitMin) * math.Pow(2, float64(attempt))
+	if wait > float64(c.RetryWaitMax) {
+		return c.RetryWaitMax
+	}
+
+	return time.Duration(wait)
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
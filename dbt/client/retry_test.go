@@ -0,0 +1,173 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestShouldRetry(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		method   string
+		status   int
+		err      error
+		expected bool
+	}{
+		{name: "throttled GET", method: http.MethodGet, status: http.StatusTooManyRequests, expected: true},
+		{name: "throttled POST", method: http.MethodPost, status: http.StatusTooManyRequests, expected: true},
+		{name: "bad gateway GET", method: http.MethodGet, status: http.StatusBadGateway, expected: true},
+		{name: "unavailable PUT", method: http.MethodPut, status: http.StatusServiceUnavailable, expected: true},
+		{name: "gateway timeout DELETE", method: http.MethodDelete, status: http.StatusGatewayTimeout, expected: true},
+		{name: "bad gateway POST", method: http.MethodPost, status: http.StatusBadGateway, expected: false},
+		{name: "unavailable POST", method: http.MethodPost, status: http.StatusServiceUnavailable, expected: false},
+		{name: "internal server error GET", method: http.MethodGet, status: http.StatusInternalServerError, expected: false},
+		{name: "not found GET", method: http.MethodGet, status: http.StatusNotFound, expected: false},
+		{name: "ok GET", method: http.MethodGet, status: http.StatusOK, expected: false},
+		{name: "connection reset GET", method: http.MethodGet, err: fmt.Errorf("read: %w", syscall.ECONNRESET), expected: true},
+		{name: "unexpected EOF GET", method: http.MethodGet, err: io.ErrUnexpectedEOF, expected: true},
+		{name: "connection reset POST", method: http.MethodPost, err: syscall.ECONNRESET, expected: false},
+		{name: "other error GET", method: http.MethodGet, err: errors.New("no such host"), expected: false},
+		{name: "cancelled context", ctx: cancelled, method: http.MethodGet, status: http.StatusTooManyRequests, expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := test.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			var response *http.Response
+			if test.err == nil {
+				response = &http.Response{StatusCode: test.status}
+			}
+
+			if actual := shouldRetry(ctx, test.method, response, test.err); actual != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRetryWait(t *testing.T) {
+	c := &Client{RetryWaitMin: 1 * time.Second, RetryWaitMax: 30 * time.Second}
+
+	tests := []struct {
+		name       string
+		attempt    int
+		retryAfter string
+		expected   time.Duration
+	}{
+		{name: "first attempt", attempt: 0, expected: 1 * time.Second},
+		{name: "second attempt", attempt: 1, expected: 2 * time.Second},
+		{name: "fourth attempt", attempt: 3, expected: 8 * time.Second},
+		{name: "capped at max", attempt: 5, expected: 30 * time.Second},
+		{name: "retry after seconds", attempt: 0, retryAfter: "12", expected: 12 * time.Second},
+		{name: "retry after takes precedence over backoff", attempt: 4, retryAfter: "3", expected: 3 * time.Second},
+		{name: "retry after is not capped", attempt: 0, retryAfter: "120", expected: 120 * time.Second},
+		{name: "retry after date in the past", attempt: 0, retryAfter: "Mon, 01 Jan 2001 00:00:00 GMT", expected: 0},
+		{name: "invalid retry after", attempt: 1, retryAfter: "soon", expected: 2 * time.Second},
+		{name: "negative retry after", attempt: 1, retryAfter: "-5", expected: 2 * time.Second},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response := &http.Response{Header: http.Header{}}
+			if test.retryAfter != "" {
+				response.Header.Set("Retry-After", test.retryAfter)
+			}
+
+			if actual := c.retryWait(test.attempt, response); actual != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	future := time.Now().Add(1 * time.Hour).UTC().Format(http.TimeFormat)
+
+	tests := []struct {
+		value      string
+		expectedOk bool
+		minimum    time.Duration
+		maximum    time.Duration
+	}{
+		{value: "", expectedOk: false},
+		{value: "0", expectedOk: true, minimum: 0, maximum: 0},
+		{value: "7", expectedOk: true, minimum: 7 * time.Second, maximum: 7 * time.Second},
+		{value: "-1", expectedOk: false},
+		{value: "1.5", expectedOk: false},
+		{value: future, expectedOk: true, minimum: 58 * time.Minute, maximum: 1 * time.Hour},
+		{value: "Mon, 01 Jan 2001 00:00:00 GMT", expectedOk: true, minimum: 0, maximum: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			wait, ok := parseRetryAfter(test.value)
+			if ok != test.expectedOk {
+				t.Fatalf("expected ok to be %v, got %v", test.expectedOk, ok)
+			}
+
+			if wait < test.minimum || wait > test.maximum {
+				t.Errorf("expected a wait between %s and %s, got %s", test.minimum, test.maximum, wait)
+			}
+		})
+	}
+}
+
+func TestDoRetries(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		statuses         []int
+		expectedRequests int
+		expectedError    bool
+	}{
+		{name: "GET retried until it succeeds", method: http.MethodGet, statuses: []int{503, 502, 200}, expectedRequests: 3},
+		{name: "POST retried when throttled", method: http.MethodPost, statuses: []int{429, 200}, expectedRequests: 2},
+		{name: "POST not retried on 5xx", method: http.MethodPost, statuses: []int{503, 200}, expectedRequests: 1, expectedError: true},
+		{name: "GET gives up after max retries", method: http.MethodGet, statuses: []int{503, 503, 503, 503}, expectedRequests: 3, expectedError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := test.statuses[requests]
+				requests++
+
+				if status != http.StatusOK {
+					w.Header().Set("Retry-After", "0")
+				}
+				w.WriteHeader(status)
+				w.Write([]byte(`{"data": {}}`))
+			}))
+			defer server.Close()
+
+			c := NewClient(server.URL, "token", 1)
+			c.MaxRetries = 2
+
+			_, err := c.Do(context.Background(), test.method, "/", nil)
+
+			if (err != nil) != test.expectedError {
+				t.Errorf("expected error to be %v, got %v", test.expectedError, err)
+			}
+
+			if requests != test.expectedRequests {
+				t.Errorf("expected %d requests, got %d", test.expectedRequests, requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package client
+
+// DBT does not delete most objects, it sets their state to StateDeleted. Deleted objects are still
+// returned by the api, and must be treated as gone.
+const (
+	StateActive  = 1
+	StateDeleted = 2
+)
@@ -0,0 +1,78 @@
+package dbtconnection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"terraform-provider-dbt/dbt/client"
+)
+
+func CreateConnection(ctx context.Context, c *client.Client, connectionInput *Connection) (*Connection, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/connections/", connectionInput.AccountId, connectionInput.ProjectId)
+
+	connection, err := client.Post[Connection](ctx, c, path, connectionInput)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in CreateConnection",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return connection, nil
+}
+
+func UpdateConnection(ctx context.Context, c *client.Client, connectionInput *Connection) (*Connection, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/connections/%d/", connectionInput.AccountId, connectionInput.ProjectId, connectionInput.Id)
+
+	connection, err := client.Post[Connection](ctx, c, path, connectionInput)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in UpdateConnection",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return connection, nil
+}
+
+func ReadConnection(ctx context.Context, c *client.Client, accountId int, projectId int, connectionId int) (*Connection, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/connections/%d/", accountId, projectId, connectionId)
+
+	connection, err := client.Get[Connection](ctx, c, path)
+	if client.IsNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in ReadConnection",
+			Detail:   err.Error(),
+		}}
+	}
+
+	if connection.State == client.StateDeleted {
+		return nil, nil
+	}
+
+	return connection, nil
+}
+
+func DeleteConnection(ctx context.Context, c *client.Client, accountId int, projectId int, connectionId int) diag.Diagnostics {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/connections/%d/", accountId, projectId, connectionId)
+
+	err := c.Delete(ctx, path)
+	if err != nil && !client.IsNotFound(err) {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in DeleteConnection",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return nil
+}
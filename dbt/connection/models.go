@@ -0,0 +1,13 @@
+package dbtconnection
+
+// Connection is a warehouse connection. The details differ per adapter, so they are kept as a map.
+type Connection struct {
+	Id        int                    `json:"id,omitempty"`
+	AccountId int                    `json:"account_id"`
+	ProjectId int                    `json:"project_id"`
+	Name      string                 `json:"name"`
+	Type      string                 `json:"type"`
+	State     int                    `json:"state,omitempty"`
+	Details   map[string]interface{} `json:"details"`
+	AdapterId int                    `json:"adapter_id,omitempty"`
+}
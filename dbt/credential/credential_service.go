@@ -0,0 +1,78 @@
+package dbtcredential
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"terraform-provider-dbt/dbt/client"
+)
+
+func CreateCredential(ctx context.Context, c *client.Client, credentialInput *Credential) (*Credential, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/credentials/", credentialInput.AccountId, credentialInput.ProjectId)
+
+	credential, err := client.Post[Credential](ctx, c, path, credentialInput)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in CreateCredential",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return credential, nil
+}
+
+func UpdateCredential(ctx context.Context, c *client.Client, credentialInput *Credential) (*Credential, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/credentials/%d/", credentialInput.AccountId, credentialInput.ProjectId, credentialInput.Id)
+
+	credential, err := client.Post[Credential](ctx, c, path, credentialInput)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in UpdateCredential",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return credential, nil
+}
+
+func ReadCredential(ctx context.Context, c *client.Client, accountId int, projectId int, credentialId int) (*Credential, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/credentials/%d/", accountId, projectId, credentialId)
+
+	credential, err := client.Get[Credential](ctx, c, path)
+	if client.IsNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in ReadCredential",
+			Detail:   err.Error(),
+		}}
+	}
+
+	if credential.State == client.StateDeleted {
+		return nil, nil
+	}
+
+	return credential, nil
+}
+
+func DeleteCredential(ctx context.Context, c *client.Client, accountId int, projectId int, credentialId int) diag.Diagnostics {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/credentials/%d/", accountId, projectId, credentialId)
+
+	err := c.Delete(ctx, path)
+	if err != nil && !client.IsNotFound(err) {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in DeleteCredential",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package dbtcredential
+
+// Credential holds the warehouse credentials of a deployment environment. Only the fields of the adapter in Type are used,
+// secrets are sent to DBT but never returned.
+type Credential struct {
+	Id                   int                    `json:"id,omitempty"`
+	AccountId            int                    `json:"account_id"`
+	ProjectId            int                    `json:"project_id"`
+	Type                 string                 `json:"type"`
+	State                int                    `json:"state,omitempty"`
+	Threads              int                    `json:"threads"`
+	TargetName           string                 `json:"target_name,omitempty"`
+	User                 string                 `json:"user,omitempty"`
+	Username             string                 `json:"username,omitempty"`
+	Schema               string                 `json:"schema,omitempty"`
+	DefaultSchema        string                 `json:"default_schema,omitempty"`
+	Dataset              string                 `json:"dataset,omitempty"`
+	AuthType             string                 `json:"auth_type,omitempty"`
+	Password             string                 `json:"password,omitempty"`
+	PrivateKey           string                 `json:"private_key,omitempty"`
+	PrivateKeyPassphrase string                 `json:"private_key_passphrase,omitempty"`
+	AdapterId            int                    `json:"adapter_id,omitempty"`
+	CredentialDetails    map[string]interface{} `json:"credential_details,omitempty"`
+}
@@ -0,0 +1,73 @@
+package dbt
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"terraform-provider-dbt/dbt/client"
+	dbtlicensemap "terraform-provider-dbt/dbt/license_map"
+)
+
+func dataSourceLicenseMaps() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceLicenseMapsRead,
+		Schema: map[string]*schema.Schema{
+			"license_maps": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "All license maps in the account, including the sso groups not managed by terraform",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"license_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "1 for active and 2 for deleted license maps",
+						},
+						"sso_license_mapping_groups": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceLicenseMapsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	licenseMaps, diags := dbtlicensemap.ListLicenseMaps(ctx, c, c.AccountId)
+	if diags != nil {
+		return diags
+	}
+
+	flattened := make([]interface{}, len(*licenseMaps))
+	for i, licenseMap := range *licenseMaps {
+		flattened[i] = map[string]interface{}{
+			"id":                         licenseMap.Id,
+			"license_type":               licenseMap.LicenseType,
+			"state":                      licenseMap.State,
+			"sso_license_mapping_groups": licenseMap.SsoLicenseMappingGroups,
+		}
+	}
+
+	d.SetId(strconv.Itoa(c.AccountId))
+	d.Set("license_maps", flattened)
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package dbt
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"terraform-provider-dbt/dbt/client"
+	dbtusergroup "terraform-provider-dbt/dbt/user_group"
+)
+
+func dataSourceUserGroup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceUserGroupRead,
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"group_id", "name"},
+				Description:  "Id of the group to look up",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"group_id", "name"},
+				Description:  "Name of the group to look up",
+			},
+			"assign_by_default": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "True if users are automatically added to the group",
+			},
+			"sso_mapping_groups": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Name of the sso groups this group is mapped to",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"group_permissions": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"permission_set": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"project_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"all_projects": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUserGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	groupId := d.Get("group_id").(int)
+	if groupId == 0 {
+		name := d.Get("name").(string)
+
+		group, diags := dbtusergroup.FindUserGroupByName(ctx, c, c.AccountId, name)
+		if diags != nil {
+			return diags
+		}
+
+		if group == nil {
+			return diag.Errorf("Could not find a DBT group named %q", name)
+		}
+
+		groupId = group.Id
+	}
+
+	group, diags := dbtusergroup.ReadUserGroup(ctx, c, &dbtusergroup.UserGroup{Id: groupId, AccountId: c.AccountId})
+	if diags != nil {
+		return diags
+	}
+
+	if group == nil {
+		return diag.Errorf("Could not find a DBT group with id %d", groupId)
+	}
+
+	d.SetId(strconv.Itoa(group.Id))
+	d.Set("group_id", group.Id)
+	d.Set("name", group.Name)
+	d.Set("assign_by_default", group.AssignByDefault)
+	d.Set("sso_mapping_groups", group.SsoMappingUserGroups)
+	d.Set("group_permissions", flattenUserGroupPermissions(group.UserGroupPermissions))
+
+	return nil
+}
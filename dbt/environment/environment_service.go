@@ -0,0 +1,78 @@
+package dbtenvironment
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"terraform-provider-dbt/dbt/client"
+)
+
+func CreateEnvironment(ctx context.Context, c *client.Client, environmentInput *Environment) (*Environment, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/environments/", environmentInput.AccountId, environmentInput.ProjectId)
+
+	environment, err := client.Post[Environment](ctx, c, path, environmentInput)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in CreateEnvironment",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return environment, nil
+}
+
+func UpdateEnvironment(ctx context.Context, c *client.Client, environmentInput *Environment) (*Environment, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/environments/%d/", environmentInput.AccountId, environmentInput.ProjectId, environmentInput.Id)
+
+	environment, err := client.Post[Environment](ctx, c, path, environmentInput)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in UpdateEnvironment",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return environment, nil
+}
+
+func ReadEnvironment(ctx context.Context, c *client.Client, accountId int, projectId int, environmentId int) (*Environment, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/environments/%d/", accountId, projectId, environmentId)
+
+	environment, err := client.Get[Environment](ctx, c, path)
+	if client.IsNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in ReadEnvironment",
+			Detail:   err.Error(),
+		}}
+	}
+
+	if environment.State == client.StateDeleted {
+		return nil, nil
+	}
+
+	return environment, nil
+}
+
+func DeleteEnvironment(ctx context.Context, c *client.Client, accountId int, projectId int, environmentId int) diag.Diagnostics {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/environments/%d/", accountId, projectId, environmentId)
+
+	err := c.Delete(ctx, path)
+	if err != nil && !client.IsNotFound(err) {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in DeleteEnvironment",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return nil
+}
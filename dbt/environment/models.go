@@ -0,0 +1,16 @@
+package dbtenvironment
+
+type Environment struct {
+	Id              int     `json:"id,omitempty"`
+	AccountId       int     `json:"account_id"`
+	ProjectId       int     `json:"project_id"`
+	Name            string  `json:"name"`
+	Type            string  `json:"type"`
+	DeploymentType  *string `json:"deployment_type"`
+	DbtVersion      string  `json:"dbt_version,omitempty"`
+	CustomBranch    *string `json:"custom_branch"`
+	UseCustomBranch bool    `json:"use_custom_branch"`
+	CredentialId    *int    `json:"credentials_id"`
+	ConnectionId    *int    `json:"connection_id"`
+	State           int     `json:"state,omitempty"`
+}
@@ -0,0 +1,106 @@
+package dbtenvironmentvariable
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"terraform-provider-dbt/dbt/client"
+)
+
+func CreateEnvironmentVariable(ctx context.Context, c *client.Client, environmentVariableInput *EnvironmentVariable) diag.Diagnostics {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/environment-variables/bulk/", environmentVariableInput.AccountId, environmentVariableInput.ProjectId)
+
+	_, err := client.Post[interface{}](ctx, c, path, environmentVariableInput)
+	if err != nil {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in CreateEnvironmentVariable",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return nil
+}
+
+// UpdateEnvironmentVariable sets the values of all environments in the input, and removes the values of the
+// environments that are not in it.
+func UpdateEnvironmentVariable(ctx context.Context, c *client.Client, environmentVariableInput *EnvironmentVariable) diag.Diagnostics {
+	current, diags := ReadEnvironmentVariable(ctx, c, environmentVariableInput.AccountId, environmentVariableInput.ProjectId, environmentVariableInput.Name)
+	if diags != nil {
+		return diags
+	}
+
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/environment-variables/bulk/", environmentVariableInput.AccountId, environmentVariableInput.ProjectId)
+
+	_, err := client.Put[interface{}](ctx, c, path, environmentVariableInput)
+	if err != nil {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in UpdateEnvironmentVariable",
+			Detail:   err.Error(),
+		}}
+	}
+
+	for environment, value := range current {
+		if _, ok := environmentVariableInput.EnvironmentValues[environment]; ok {
+			continue
+		}
+
+		path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/environment-variables/%d/", environmentVariableInput.AccountId, environmentVariableInput.ProjectId, value.Id)
+
+		err := c.Delete(ctx, path)
+		if err != nil && !client.IsNotFound(err) {
+			return diag.Diagnostics{diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Dbt returned an error in UpdateEnvironmentVariable",
+				Detail:   err.Error(),
+			}}
+		}
+	}
+
+	return nil
+}
+
+// ReadEnvironmentVariable returns the values of the environment variable keyed by environment name, or nil if the
+// variable does not exist.
+func ReadEnvironmentVariable(ctx context.Context, c *client.Client, accountId int, projectId int, name string) (map[string]EnvironmentVariableValue, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/environment-variables/environment/", accountId, projectId)
+
+	environmentVariables, err := client.Get[map[string]map[string]EnvironmentVariableValue](ctx, c, path)
+	if client.IsNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in ReadEnvironmentVariable",
+			Detail:   err.Error(),
+		}}
+	}
+
+	values, ok := (*environmentVariables)[name]
+	if !ok {
+		return nil, nil
+	}
+
+	return values, nil
+}
+
+func DeleteEnvironmentVariable(ctx context.Context, c *client.Client, accountId int, projectId int, name string) diag.Diagnostics {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/environment-variables/bulk/", accountId, projectId)
+
+	_, err := c.Do(ctx, http.MethodDelete, path, map[string]interface{}{"env_var_names": []string{name}})
+	if err != nil && !client.IsNotFound(err) {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in DeleteEnvironmentVariable",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return nil
+}
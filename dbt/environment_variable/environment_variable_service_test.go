@@ -0,0 +1,53 @@
+package dbtenvironmentvariable
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"terraform-provider-dbt/dbt/client"
+)
+
+func TestUpdateEnvironmentVariable(t *testing.T) {
+	var requests []string
+	var body EnvironmentVariable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v3/accounts/1/projects/2/environment-variables/environment/":
+			w.Write([]byte(`{"data":{"DBT_TARGET":{"project":{"id":10,"value":"dev"},"Production":{"id":11,"value":"prod"},"Staging":{"id":12,"value":"staging"}}}}`))
+		case "PUT /api/v3/accounts/1/projects/2/environment-variables/bulk/":
+			json.NewDecoder(r.Body).Decode(&body)
+			w.Write([]byte(`{"data":"Updated 2 environment variables"}`))
+		case "DELETE /api/v3/accounts/1/projects/2/environment-variables/12/":
+			w.Write([]byte(`{"data":null}`))
+		default:
+			http.Error(w, fmt.Sprintf("unexpected request %s %s", r.Method, r.URL.Path), http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	input := &EnvironmentVariable{AccountId: 1, ProjectId: 2, Name: "DBT_TARGET", EnvironmentValues: map[string]string{"project": "dev", "Production": "production"}}
+	diags := UpdateEnvironmentVariable(context.Background(), client.NewClient(server.URL, "token", 1), input)
+	if diags.HasError() {
+		t.Fatalf("expected no error, got %v", diags)
+	}
+
+	if !reflect.DeepEqual(body, *input) {
+		t.Errorf("expected the values %v to be put, got %v", *input, body)
+	}
+
+	expectedRequests := []string{
+		"GET /api/v3/accounts/1/projects/2/environment-variables/environment/",
+		"PUT /api/v3/accounts/1/projects/2/environment-variables/bulk/",
+		"DELETE /api/v3/accounts/1/projects/2/environment-variables/12/",
+	}
+	if !reflect.DeepEqual(requests, expectedRequests) {
+		t.Errorf("expected requests %v, got %v", expectedRequests, requests)
+	}
+}
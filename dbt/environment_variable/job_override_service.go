@@ -0,0 +1,74 @@
+package dbtenvironmentvariable
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"terraform-provider-dbt/dbt/client"
+)
+
+func CreateJobOverride(ctx context.Context, c *client.Client, jobOverrideInput *JobOverride) (*JobOverride, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/environment-variables/", jobOverrideInput.AccountId, jobOverrideInput.ProjectId)
+
+	jobOverride, err := client.Post[JobOverride](ctx, c, path, jobOverrideInput)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in CreateJobOverride",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return jobOverride, nil
+}
+
+func UpdateJobOverride(ctx context.Context, c *client.Client, jobOverrideInput *JobOverride) (*JobOverride, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/environment-variables/%d/", jobOverrideInput.AccountId, jobOverrideInput.ProjectId, jobOverrideInput.Id)
+
+	jobOverride, err := client.Post[JobOverride](ctx, c, path, jobOverrideInput)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in UpdateJobOverride",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return jobOverride, nil
+}
+
+func ReadJobOverride(ctx context.Context, c *client.Client, accountId int, projectId int, jobOverrideId int) (*JobOverride, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/environment-variables/%d/", accountId, projectId, jobOverrideId)
+
+	jobOverride, err := client.Get[JobOverride](ctx, c, path)
+	if client.IsNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in ReadJobOverride",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return jobOverride, nil
+}
+
+func DeleteJobOverride(ctx context.Context, c *client.Client, accountId int, projectId int, jobOverrideId int) diag.Diagnostics {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/environment-variables/%d/", accountId, projectId, jobOverrideId)
+
+	err := c.Delete(ctx, path)
+	if err != nil && !client.IsNotFound(err) {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in DeleteJobOverride",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return nil
+}
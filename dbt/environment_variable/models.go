@@ -0,0 +1,33 @@
+package dbtenvironmentvariable
+
+// SecretPrefix marks environment variables whose values DBT masks in the api and in logs.
+const SecretPrefix = "DBT_ENV_SECRET_"
+
+// ProjectEnvironment is the key of the project default value in EnvironmentValues.
+const ProjectEnvironment = "project"
+
+// EnvironmentVariable is a project environment variable with its values keyed by environment name.
+type EnvironmentVariable struct {
+	AccountId         int               `json:"account_id"`
+	ProjectId         int               `json:"project_id"`
+	Name              string            `json:"name"`
+	EnvironmentValues map[string]string `json:"environment_values"`
+}
+
+// EnvironmentVariableValue is the value of an environment variable in one environment, as returned by DBT.
+// Values of secret variables are masked.
+type EnvironmentVariableValue struct {
+	Id    int    `json:"id"`
+	Value string `json:"value"`
+}
+
+// JobOverride is the value of an environment variable for a single job, taking precedence over the environment values.
+type JobOverride struct {
+	Id              int    `json:"id,omitempty"`
+	AccountId       int    `json:"account_id"`
+	ProjectId       int    `json:"project_id"`
+	JobDefinitionId int    `json:"job_definition_id"`
+	Name            string `json:"name"`
+	Type            string `json:"type"`
+	RawValue        string `json:"raw_value"`
+}
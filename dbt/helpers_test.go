@@ -0,0 +1,53 @@
+package dbt
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	utils "terraform-provider-dbt/dbt/utils"
+)
+
+// planResource plans config against the prior state like terraform does, including CustomizeDiff. An empty id
+// plans the creation of the resource. The attributes named in unknown, at any depth, are not known until apply,
+// like references to resources that are not created yet.
+func planResource(t *testing.T, r *schema.Resource, id string, attributes map[string]string, config map[string]interface{}, meta interface{}, unknown ...string) (*terraform.InstanceDiff, error) {
+	t.Helper()
+
+	configJson, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configVal, err := ctyjson.Unmarshal(configJson, r.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configVal, err = cty.Transform(configVal, func(path cty.Path, value cty.Value) (cty.Value, error) {
+		if len(path) == 0 {
+			return value, nil
+		}
+
+		if step, ok := path[len(path)-1].(cty.GetAttrStep); ok && !value.IsNull() && utils.Contains(unknown, step.Name) {
+			return cty.UnknownVal(value.Type()), nil
+		}
+
+		return value, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := &terraform.InstanceState{ID: id, Attributes: attributes, RawConfig: configVal}
+	if id != "" && state.Attributes == nil {
+		state.Attributes = map[string]string{"id": id}
+	}
+
+	return r.SimpleDiff(context.Background(), state, terraform.NewResourceConfigShimmed(configVal, r.CoreConfigSchema()), meta)
+}
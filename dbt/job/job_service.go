@@ -0,0 +1,78 @@
+package dbtjob
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"terraform-provider-dbt/dbt/client"
+)
+
+func CreateJob(ctx context.Context, c *client.Client, jobInput *Job) (*Job, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v2/accounts/%d/jobs/", jobInput.AccountId)
+
+	job, err := client.Post[Job](ctx, c, path, jobInput)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in CreateJob",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return job, nil
+}
+
+func UpdateJob(ctx context.Context, c *client.Client, jobInput *Job) (*Job, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v2/accounts/%d/jobs/%d/", jobInput.AccountId, jobInput.Id)
+
+	job, err := client.Post[Job](ctx, c, path, jobInput)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in UpdateJob",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return job, nil
+}
+
+func ReadJob(ctx context.Context, c *client.Client, accountId int, jobId int) (*Job, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v2/accounts/%d/jobs/%d/", accountId, jobId)
+
+	job, err := client.Get[Job](ctx, c, path)
+	if client.IsNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in ReadJob",
+			Detail:   err.Error(),
+		}}
+	}
+
+	if job.State == client.StateDeleted {
+		return nil, nil
+	}
+
+	return job, nil
+}
+
+func DeleteJob(ctx context.Context, c *client.Client, accountId int, jobId int) diag.Diagnostics {
+	path := fmt.Sprintf("/api/v2/accounts/%d/jobs/%d/", accountId, jobId)
+
+	err := c.Delete(ctx, path)
+	if err != nil && !client.IsNotFound(err) {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in DeleteJob",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return nil
+}
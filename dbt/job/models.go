@@ -0,0 +1,61 @@
+package dbtjob
+
+type Job struct {
+	Id                            int                   `json:"id,omitempty"`
+	AccountId                     int                   `json:"account_id"`
+	ProjectId                     int                   `json:"project_id"`
+	EnvironmentId                 int                   `json:"environment_id"`
+	Name                          string                `json:"name"`
+	Description                   string                `json:"description"`
+	ExecuteSteps                  []string              `json:"execute_steps"`
+	DbtVersion                    *string               `json:"dbt_version"`
+	Triggers                      JobTriggers           `json:"triggers"`
+	Settings                      JobSettings           `json:"settings"`
+	Schedule                      JobSchedule           `json:"schedule"`
+	GenerateDocs                  bool                  `json:"generate_docs"`
+	RunGenerateSources            bool                  `json:"run_generate_sources"`
+	TimeoutSeconds                int                   `json:"timeout_seconds"`
+	DeferringEnvironmentId        *int                  `json:"deferring_environment_id"`
+	JobCompletionTriggerCondition *JobCompletionTrigger `json:"job_completion_trigger_condition"`
+	State                         int                   `json:"state"`
+}
+
+type JobTriggers struct {
+	GithubWebhook      bool `json:"github_webhook"`
+	GitProviderWebhook bool `json:"git_provider_webhook"`
+	Schedule           bool `json:"schedule"`
+	OnMerge            bool `json:"on_merge"`
+}
+
+type JobSettings struct {
+	Threads    int    `json:"threads"`
+	TargetName string `json:"target_name"`
+}
+
+type JobSchedule struct {
+	Cron string          `json:"cron"`
+	Date JobScheduleDate `json:"date"`
+	Time JobScheduleTime `json:"time"`
+}
+
+type JobScheduleDate struct {
+	Type string  `json:"type"`
+	Days []int   `json:"days,omitempty"`
+	Cron *string `json:"cron,omitempty"`
+}
+
+type JobScheduleTime struct {
+	Type     string `json:"type"`
+	Interval int    `json:"interval,omitempty"`
+	Hours    []int  `json:"hours,omitempty"`
+}
+
+type JobCompletionTrigger struct {
+	Condition JobCompletionTriggerCondition `json:"condition"`
+}
+
+type JobCompletionTriggerCondition struct {
+	JobId     int   `json:"job_id"`
+	ProjectId int   `json:"project_id"`
+	Statuses  []int `json:"statuses"`
+}
@@ -0,0 +1,69 @@
+package dbtlicensemap
+
+import (
+	"fmt"
+	"sync"
+)
+
+var claimsLock sync.Mutex
+
+// claim is a dbt_license_map planned in this provider process.
+type claim struct {
+	claimant      string
+	authoritative bool
+	priorState    bool
+	replaced      bool
+}
+
+// claims records which license types are managed by a dbt_license_map, keyed by account and license type.
+// The claims live as long as the provider process, which terraform starts for every plan and apply.
+var claims = map[string][]*claim{}
+
+// ClaimLicenseType registers that a dbt_license_map manages licenseType. It fails when an authoritative
+// resource would share the license type with any other dbt_license_map, since they would keep
+// removing each others groups.
+//
+// The check is best-effort. Terraform does not tell the provider which resource is planned, so claimant
+// identifies the resource by its configuration, and priorState tells whether it already exists. Terraform
+// plans a replaced resource twice, first with and then without its prior state, so that sequence is counted
+// as one resource. Two resources with the same configuration are therefore not told apart if one of them
+// exists and the other one is new. Resources in other workspaces are never detected.
+func ClaimLicenseType(accountId int, licenseType string, claimant string, priorState bool, authoritative bool) error {
+	claimsLock.Lock()
+	defer claimsLock.Unlock()
+
+	key := fmt.Sprintf("%d:%s", accountId, licenseType)
+
+	var replacement *claim
+	for _, existing := range claims[key] {
+		if existing.claimant == claimant && existing.priorState && !priorState && !existing.replaced {
+			replacement = existing
+			break
+		}
+	}
+
+	for _, existing := range claims[key] {
+		if existing != replacement && (authoritative || existing.authoritative) {
+			return fmt.Errorf("the %q license type is managed by more than one dbt_license_map, and at least one of them is authoritative. An authoritative dbt_license_map must be the only dbt_license_map for its license type", licenseType)
+		}
+	}
+
+	if replacement != nil {
+		replacement.replaced = true
+		replacement.authoritative = authoritative
+		return nil
+	}
+
+	claims[key] = append(claims[key], &claim{claimant: claimant, authoritative: authoritative, priorState: priorState})
+
+	return nil
+}
+
+// ResetClaims forgets all claims. It is only needed when several configurations are planned in one process,
+// like in tests.
+func ResetClaims() {
+	claimsLock.Lock()
+	defer claimsLock.Unlock()
+
+	claims = map[string][]*claim{}
+}
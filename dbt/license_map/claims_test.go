@@ -0,0 +1,83 @@
+package dbtlicensemap
+
+import "testing"
+
+func TestClaimLicenseType(t *testing.T) {
+	type testClaim struct {
+		claimant      string
+		priorState    bool
+		authoritative bool
+		expectError   bool
+	}
+
+	tests := []struct {
+		name   string
+		claims []testClaim
+	}{
+		{
+			name:   "additive resources can share a license type",
+			claims: []testClaim{{claimant: "a"}, {claimant: "b"}},
+		},
+		{
+			name:   "additive resources with the same configuration",
+			claims: []testClaim{{claimant: "a"}, {claimant: "a"}},
+		},
+		{
+			name:   "replaced authoritative resource",
+			claims: []testClaim{{claimant: "a", priorState: true, authoritative: true}, {claimant: "a", authoritative: true}},
+		},
+		{
+			name:   "resource replaced to become authoritative",
+			claims: []testClaim{{claimant: "a", priorState: true, authoritative: true}, {claimant: "a", authoritative: true}, {claimant: "b", expectError: true}},
+		},
+		{
+			name:   "new authoritative resources with the same configuration",
+			claims: []testClaim{{claimant: "a", authoritative: true}, {claimant: "a", authoritative: true, expectError: true}},
+		},
+		{
+			name:   "existing authoritative resources with the same configuration",
+			claims: []testClaim{{claimant: "a", priorState: true, authoritative: true}, {claimant: "a", priorState: true, authoritative: true, expectError: true}},
+		},
+		{
+			name: "replaced and new authoritative resources with the same configuration",
+			claims: []testClaim{
+				{claimant: "a", priorState: true, authoritative: true},
+				{claimant: "a", authoritative: true},
+				{claimant: "a", authoritative: true, expectError: true},
+			},
+		},
+		{
+			name:   "two authoritative resources",
+			claims: []testClaim{{claimant: "a", authoritative: true}, {claimant: "b", authoritative: true, expectError: true}},
+		},
+		{
+			name:   "additive after authoritative",
+			claims: []testClaim{{claimant: "a", authoritative: true}, {claimant: "b", expectError: true}},
+		},
+		{
+			name:   "authoritative after additive",
+			claims: []testClaim{{claimant: "a"}, {claimant: "b", authoritative: true, expectError: true}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Cleanup(ResetClaims)
+
+			for i, claim := range test.claims {
+				err := ClaimLicenseType(1, "developer", claim.claimant, claim.priorState, claim.authoritative)
+				if (err != nil) != claim.expectError {
+					t.Fatalf("claim %d by %s: expected error to be %v, got %v", i, claim.claimant, claim.expectError, err)
+				}
+			}
+
+			if err := ClaimLicenseType(1, "read_only", "c", false, true); err != nil {
+				t.Errorf("expected claims of other license types to be independent, got %s", err)
+			}
+
+			if err := ClaimLicenseType(2, "developer", "c", false, true); err != nil {
+				t.Errorf("expected claims of other accounts to be independent, got %s", err)
+			}
+		})
+	}
+}
@@ -1,20 +1,22 @@
 package dbtlicensemap
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"net/http"
-	"sync"
-	"terraform-provider-dbt/dbt/utils"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"terraform-provider-dbt/dbt/client"
+	"terraform-provider-dbt/dbt/utils"
 )
 
-var lock sync.Mutex
+// lock serializes updates of license maps, since every update rewrites the full list of groups.
+// It is a channel instead of a mutex so that waiting for it can be cancelled.
+var lock = make(chan struct{}, 1)
 
-func ReadLicenseMap(accountId int, licenseType string, ssoLicenseMappingGroups []string, serviceToken string) (*LicenseMap, diag.Diagnostics) {
-	licenseMap, diags := readLicenseMapFromLicenseType(accountId, serviceToken, licenseType)
+func ReadLicenseMap(ctx context.Context, c *client.Client, accountId int, licenseType string, ssoLicenseMappingGroups []string) (*LicenseMap, diag.Diagnostics) {
+	licenseMap, diags := readLicenseMapFromLicenseType(ctx, c, accountId, licenseType)
 
 	if diags != nil {
 		return nil, diags
@@ -36,10 +38,59 @@ func ReadLicenseMap(accountId int, licenseType string, ssoLicenseMappingGroups [
 	return licenseMap, nil
 }
 
-func readLicenseMapFromLicenseType(accountId int, serviceToken string, licenseType string) (*LicenseMap, diag.Diagnostics) {
-	url := fmt.Sprintf("https://cloud.getdbt.com/api/v3/accounts/%d/license-maps/", accountId)
+// ReadAuthoritativeLicenseMap returns the license map with all of its groups, also those not managed by terraform.
+func ReadAuthoritativeLicenseMap(ctx context.Context, c *client.Client, accountId int, licenseType string) (*LicenseMap, diag.Diagnostics) {
+	return readLicenseMapFromLicenseType(ctx, c, accountId, licenseType)
+}
 
-	getLicenseMapsResponse, err := utils.GetAsObject[GetLicenseMapsResponse](url, serviceToken)
+func readLicenseMapFromLicenseType(ctx context.Context, c *client.Client, accountId int, licenseType string) (*LicenseMap, diag.Diagnostics) {
+	licenseMaps, diags := ListLicenseMaps(ctx, c, accountId)
+
+	if diags != nil {
+		return nil, diags
+	}
+
+	for _, val := range *licenseMaps {
+		if val.LicenseType == licenseType && val.State != client.StateDeleted {
+			return &val, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// readLicenseMapForUpdate returns the active license map for the license type, or a deleted one that can be
+// activated again, so that a new license map is only created when the license type never had one.
+func readLicenseMapForUpdate(ctx context.Context, c *client.Client, accountId int, licenseType string) (*LicenseMap, diag.Diagnostics) {
+	licenseMaps, diags := ListLicenseMaps(ctx, c, accountId)
+
+	if diags != nil {
+		return nil, diags
+	}
+
+	var deletedLicenseMap *LicenseMap
+	for i := range *licenseMaps {
+		licenseMap := &(*licenseMaps)[i]
+		if licenseMap.LicenseType != licenseType {
+			continue
+		}
+
+		if licenseMap.State != client.StateDeleted {
+			return licenseMap, nil
+		}
+
+		if deletedLicenseMap == nil {
+			deletedLicenseMap = licenseMap
+		}
+	}
+
+	return deletedLicenseMap, nil
+}
+
+func ListLicenseMaps(ctx context.Context, c *client.Client, accountId int) (*[]LicenseMap, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/license-maps/", accountId)
+
+	licenseMaps, err := client.Get[[]LicenseMap](ctx, c, path)
 
 	if err != nil {
 		return nil, diag.Diagnostics{diag.Diagnostic{
@@ -49,26 +100,71 @@ func readLicenseMapFromLicenseType(accountId int, serviceToken string, licenseTy
 		}}
 	}
 
-	for _, val := range getLicenseMapsResponse.Data {
-		if val.LicenseType == licenseType {
-			return &val, nil
+	return licenseMaps, nil
+}
+
+func CreateOrUpdateLicenseMap(ctx context.Context, c *client.Client, accountId int, licenseType string, mappingsToAdd []string, mappingsToRemove []string) (*LicenseMap, diag.Diagnostics) {
+	select {
+	case lock <- struct{}{}:
+		defer func() { <-lock }()
+	case <-ctx.Done():
+		return nil, diag.FromErr(ctx.Err())
+	}
+
+	existingLicenceMap, diags := readLicenseMapForUpdate(ctx, c, accountId, licenseType)
+
+	if diags != nil {
+		return nil, diags
+	}
+
+	var existingGroups []string
+	if existingLicenceMap != nil && existingLicenceMap.State != client.StateDeleted {
+		existingGroups = existingLicenceMap.SsoLicenseMappingGroups
+	}
+
+	return writeLicenseMap(ctx, c, accountId, licenseType, existingLicenceMap, mergeGroups(existingGroups, mappingsToAdd, mappingsToRemove))
+}
+
+// mergeGroups removes mappingsToRemove from the existing groups and adds mappingsToAdd, without duplicates.
+// A group in both lists is kept, so the old and new groups of a resource can be passed as they are.
+func mergeGroups(existingGroups []string, mappingsToAdd []string, mappingsToRemove []string) []string {
+	var groups []string
+	for _, group := range append(append([]string{}, existingGroups...), mappingsToAdd...) {
+		if utils.Contains(groups, group) {
+			continue
 		}
+
+		if utils.Contains(mappingsToRemove, group) && !utils.Contains(mappingsToAdd, group) {
+			continue
+		}
+
+		groups = append(groups, group)
 	}
 
-	return nil, nil
+	return groups
 }
 
-func CreateOrUpdateLicenseMap(accountId int, licenseType string, mappingsToAdd []string, mappingsToRemove []string, serviceToken string) (*LicenseMap, diag.Diagnostics) {
-	lock.Lock()
-	defer lock.Unlock()
-	existingLicenceMap, diags := readLicenseMapFromLicenseType(accountId, serviceToken, licenseType)
+// SetLicenseMapGroups replaces all groups of the license map with ssoLicenseMappingGroups, removing groups
+// added outside of terraform.
+func SetLicenseMapGroups(ctx context.Context, c *client.Client, accountId int, licenseType string, ssoLicenseMappingGroups []string) (*LicenseMap, diag.Diagnostics) {
+	select {
+	case lock <- struct{}{}:
+		defer func() { <-lock }()
+	case <-ctx.Done():
+		return nil, diag.FromErr(ctx.Err())
+	}
+
+	existingLicenceMap, diags := readLicenseMapForUpdate(ctx, c, accountId, licenseType)
 
 	if diags != nil {
 		return nil, diags
 	}
 
-	var url string
-	var expectedStatusCode int
+	return writeLicenseMap(ctx, c, accountId, licenseType, existingLicenceMap, ssoLicenseMappingGroups)
+}
+
+func writeLicenseMap(ctx context.Context, c *client.Client, accountId int, licenseType string, existingLicenceMap *LicenseMap, groups []string) (*LicenseMap, diag.Diagnostics) {
+	var path string
 
 	request := LicenseMap{
 		AccountId:   accountId,
@@ -76,49 +172,30 @@ func CreateOrUpdateLicenseMap(accountId int, licenseType string, mappingsToAdd [
 	}
 
 	if existingLicenceMap == nil {
-		url = fmt.Sprintf("https://cloud.getdbt.com/api/v3/accounts/%d/license-maps/", accountId)
-		expectedStatusCode = http.StatusCreated
+		path = fmt.Sprintf("/api/v3/accounts/%d/license-maps/", accountId)
 
-		request.SsoLicenseMappingGroups = mappingsToAdd
+		request.SsoLicenseMappingGroups = groups
 	} else {
-		url = fmt.Sprintf("https://cloud.getdbt.com/api/v3/accounts/%d/license-maps/%d/", accountId, existingLicenceMap.Id)
-		expectedStatusCode = http.StatusOK
+		path = fmt.Sprintf("/api/v3/accounts/%d/license-maps/%d/", accountId, existingLicenceMap.Id)
 
 		request.Id = existingLicenceMap.Id
-		request.SsoLicenseMappingGroups = utils.RemoveFromList(append(existingLicenceMap.SsoLicenseMappingGroups, mappingsToAdd...), mappingsToRemove)
+		request.SsoLicenseMappingGroups = groups
+		request.State = client.StateActive
 
 		if len(request.SsoLicenseMappingGroups) == 0 {
-			request.State = 2
+			request.State = client.StateDeleted
 		}
 	}
 
-	response, err := utils.PostAsJson(request, url, serviceToken)
+	licenseMap, err := client.Post[LicenseMap](ctx, c, path, request)
 	if err != nil {
-		return nil, diag.FromErr(err)
-	}
-
-	data, _ := ioutil.ReadAll(response.Body)
-	defer response.Body.Close()
-
-	if response.StatusCode != expectedStatusCode {
 		body, _ := json.Marshal(request)
 		return nil, diag.Diagnostics{diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "CreateOrUpdateLicenseMap returned error code",
-			Detail:   fmt.Sprintf("DBT returned StatusCode %d for (%s), message: %s, body: %s", response.StatusCode, url, data, body),
-		}}
-	}
-
-	var getLicenseMapResponse GetLicenseMapResponse
-	err = json.Unmarshal(data, &getLicenseMapResponse)
-
-	if err != nil {
-		return nil, diag.Diagnostics{diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Could not parse Dbt licenseMap response as json",
-			Detail:   err.Error(),
+			Detail:   fmt.Sprintf("%s, body: %s", err.Error(), body),
 		}}
 	}
 
-	return &getLicenseMapResponse.Data, nil
+	return licenseMap, nil
 }
@@ -0,0 +1,79 @@
+package dbtlicensemap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"terraform-provider-dbt/dbt/client"
+)
+
+func TestMergeGroups(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []string
+		add      []string
+		remove   []string
+		expected []string
+	}{
+		{name: "create", existing: nil, add: []string{"a"}, remove: nil, expected: []string{"a"}},
+		{name: "keeps unmanaged groups", existing: []string{"ui"}, add: []string{"a"}, remove: nil, expected: []string{"ui", "a"}},
+		{name: "unchanged update", existing: []string{"ui", "a"}, add: []string{"a"}, remove: []string{"a"}, expected: []string{"ui", "a"}},
+		{name: "update", existing: []string{"ui", "a"}, add: []string{"b"}, remove: []string{"a"}, expected: []string{"ui", "b"}},
+		{name: "adding an existing group", existing: []string{"ui", "a"}, add: []string{"a"}, remove: nil, expected: []string{"ui", "a"}},
+		{name: "delete", existing: []string{"ui", "a"}, add: nil, remove: []string{"a"}, expected: []string{"ui"}},
+		{name: "delete last group", existing: []string{"a"}, add: nil, remove: []string{"a"}, expected: nil},
+		{name: "duplicates are removed", existing: []string{"a", "a"}, add: []string{"b", "b"}, remove: nil, expected: []string{"a", "b"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := mergeGroups(test.existing, test.add, test.remove); !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestReadLicenseMapForUpdate(t *testing.T) {
+	tests := []struct {
+		name        string
+		licenseMaps string
+		expectedId  int
+	}{
+		{name: "active map", licenseMaps: `[{"id": 1, "license_type": "developer", "state": 2}, {"id": 2, "license_type": "developer", "state": 1}]`, expectedId: 2},
+		{name: "deleted map", licenseMaps: `[{"id": 1, "license_type": "read_only", "state": 1}, {"id": 3, "license_type": "developer", "state": 2}]`, expectedId: 3},
+		{name: "no map", licenseMaps: `[{"id": 1, "license_type": "read_only", "state": 1}]`, expectedId: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.Write([]byte(`{"data": ` + test.licenseMaps + `}`))
+			}))
+			defer server.Close()
+
+			licenseMap, diags := readLicenseMapForUpdate(context.Background(), client.NewClient(server.URL, "token", 1), 1, "developer")
+			if diags != nil {
+				t.Fatal(diags)
+			}
+
+			id := 0
+			if licenseMap != nil {
+				id = licenseMap.Id
+			}
+
+			if id != test.expectedId {
+				t.Errorf("expected the license map %d, got %d", test.expectedId, id)
+			}
+
+			if requests != 1 {
+				t.Errorf("expected the license maps to be listed once, got %d requests", requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package dbtlicensemap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"terraform-provider-dbt/dbt/client"
+	"terraform-provider-dbt/dbt/utils"
+)
+
+// LicenseTypes are the license types known by this provider. Other license types are checked against
+// the account at plan time, so that new seat types can be used without a new provider release.
+var LicenseTypes = []string{
+	"developer",
+	"read_only",
+	"it",
+	"analyst",
+}
+
+func IsKnownLicenseType(licenseType string) bool {
+	return utils.Contains(LicenseTypes, licenseType)
+}
+
+// ValidateLicenseTypeForAccount checks that the account has seats of the given license type. The v2 account
+// object lists the seats per license type as <license_type>_seats, e.g. developer_seats and read_only_seats.
+// This is not a documented contract for every seat type, and the service token may not be allowed to read the
+// account, so the check only ever gives warnings. DBT gets the final say when the license map is written.
+func ValidateLicenseTypeForAccount(ctx context.Context, c *client.Client, accountId int, licenseType string) diag.Diagnostics {
+	path := fmt.Sprintf("/api/v2/accounts/%d/", accountId)
+
+	account, err := client.Get[map[string]interface{}](ctx, c, path)
+	if err != nil {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Could not check the license type %q", licenseType),
+			Detail:   fmt.Sprintf("The DBT cloud account %d could not be read to check that it has seats of the license type %q: %s", accountId, licenseType, err),
+		}}
+	}
+
+	if _, ok := (*account)[licenseType+"_seats"]; !ok {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Unknown license type %q", licenseType),
+			Detail:   fmt.Sprintf("The DBT cloud account %d does not list seats of the license type %q. Check that the license type is spelled correctly, DBT will reject it if it is not supported.", accountId, licenseType),
+		}}
+	}
+
+	return nil
+}
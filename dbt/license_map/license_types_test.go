@@ -0,0 +1,56 @@
+package dbtlicensemap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"terraform-provider-dbt/dbt/client"
+)
+
+func TestValidateLicenseTypeForAccount(t *testing.T) {
+	tests := []struct {
+		name             string
+		status           int
+		account          string
+		expectedSeverity *diag.Severity
+	}{
+		{name: "account lists the seats", status: http.StatusOK, account: `{"data": {"id": 1, "developer_seats": 10, "security_seats": 2}}`},
+		{name: "account does not list the seats", status: http.StatusOK, account: `{"data": {"id": 1, "developer_seats": 10}}`, expectedSeverity: severity(diag.Warning)},
+		{name: "account cannot be read", status: http.StatusForbidden, account: `{"status": {"code": 403}}`, expectedSeverity: severity(diag.Warning)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v2/accounts/1/" {
+					t.Errorf("unexpected request to %s", r.URL.Path)
+				}
+
+				w.WriteHeader(test.status)
+				w.Write([]byte(test.account))
+			}))
+			defer server.Close()
+
+			diags := ValidateLicenseTypeForAccount(context.Background(), client.NewClient(server.URL, "token", 1), 1, "security")
+
+			if test.expectedSeverity == nil {
+				if len(diags) != 0 {
+					t.Fatalf("expected no diagnostics, got %v", diags)
+				}
+				return
+			}
+
+			if len(diags) != 1 || diags[0].Severity != *test.expectedSeverity {
+				t.Fatalf("expected one diagnostic with severity %v, got %v", *test.expectedSeverity, diags)
+			}
+		})
+	}
+}
+
+func severity(s diag.Severity) *diag.Severity {
+	return &s
+}
@@ -7,11 +7,3 @@ type LicenseMap struct {
 	SsoLicenseMappingGroups []string `json:"sso_license_mapping_groups"`
 	State                   int      `json:"state,omitempty"`
 }
-
-type GetLicenseMapsResponse struct {
-	Data []LicenseMap `json:"data"`
-}
-
-type GetLicenseMapResponse struct {
-	Data LicenseMap `json:"data"`
-}
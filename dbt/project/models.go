@@ -0,0 +1,12 @@
+package dbtproject
+
+type Project struct {
+	Id                     int     `json:"id,omitempty"`
+	AccountId              int     `json:"account_id"`
+	Name                   string  `json:"name"`
+	Description            string  `json:"description"`
+	DbtProjectSubdirectory *string `json:"dbt_project_subdirectory"`
+	ConnectionId           *int    `json:"connection_id"`
+	RepositoryId           *int    `json:"repository_id"`
+	State                  int     `json:"state,omitempty"`
+}
@@ -0,0 +1,78 @@
+package dbtproject
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"terraform-provider-dbt/dbt/client"
+)
+
+func CreateProject(ctx context.Context, c *client.Client, projectInput *Project) (*Project, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/", projectInput.AccountId)
+
+	project, err := client.Post[Project](ctx, c, path, projectInput)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in CreateProject",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return project, nil
+}
+
+func UpdateProject(ctx context.Context, c *client.Client, projectInput *Project) (*Project, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/", projectInput.AccountId, projectInput.Id)
+
+	project, err := client.Post[Project](ctx, c, path, projectInput)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in UpdateProject",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return project, nil
+}
+
+func ReadProject(ctx context.Context, c *client.Client, accountId int, projectId int) (*Project, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/", accountId, projectId)
+
+	project, err := client.Get[Project](ctx, c, path)
+	if client.IsNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in ReadProject",
+			Detail:   err.Error(),
+		}}
+	}
+
+	if project.State == client.StateDeleted {
+		return nil, nil
+	}
+
+	return project, nil
+}
+
+func DeleteProject(ctx context.Context, c *client.Client, accountId int, projectId int) diag.Diagnostics {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/", accountId, projectId)
+
+	err := c.Delete(ctx, path)
+	if err != nil && !client.IsNotFound(err) {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in DeleteProject",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return nil
+}
@@ -2,28 +2,81 @@ package dbt
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"terraform-provider-dbt/dbt/client"
 )
 
 func Provider() *schema.Provider {
 	return &schema.Provider{
 		ResourcesMap: map[string]*schema.Resource{
-			"dbt_user_group":  resourceUserUserGroup(),
-			"dbt_license_map": resourceLicenseMap(),
+			"dbt_user_group":                        resourceUserUserGroup(),
+			"dbt_license_map":                       resourceLicenseMap(),
+			"dbt_project":                           resourceProject(),
+			"dbt_project_connection":                resourceProjectConnection(),
+			"dbt_project_repository":                resourceProjectRepository(),
+			"dbt_environment":                       resourceEnvironment(),
+			"dbt_job":                               resourceJob(),
+			"dbt_connection":                        resourceConnection(),
+			"dbt_snowflake_credential":              resourceSnowflakeCredential(),
+			"dbt_bigquery_credential":               resourceBigQueryCredential(),
+			"dbt_databricks_credential":             resourceDatabricksCredential(),
+			"dbt_postgres_credential":               resourcePostgresCredential(),
+			"dbt_repository":                        resourceRepository(),
+			"dbt_environment_variable":              resourceEnvironmentVariable(),
+			"dbt_environment_variable_job_override": resourceEnvironmentVariableJobOverride(),
+			"dbt_webhook":                           resourceWebhook(),
+			"dbt_user_group_permission":             resourceUserGroupPermission(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"dbt_user_group":   dataSourceUserGroup(),
+			"dbt_license_maps": dataSourceLicenseMaps(),
 		},
-		DataSourcesMap: map[string]*schema.Resource{},
 		Schema: map[string]*schema.Schema{
 			"service_token": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The service token for api-requests to DBT. See https://docs.getdbt.com/docs/dbt-cloud/access-control/enterprise-permissions for required permission sets",
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("DBT_CLOUD_TOKEN", nil),
+				Description: "The service token for api-requests to DBT. See https://docs.getdbt.com/docs/dbt-cloud/access-control/enterprise-permissions for required permission sets. Can also be set with the DBT_CLOUD_TOKEN environment variable",
 			},
 			"account_id": {
 				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "The account id for DBT cloud",
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("DBT_CLOUD_ACCOUNT_ID", nil),
+				Description: "The account id for DBT cloud. Can also be set with the DBT_CLOUD_ACCOUNT_ID environment variable",
+			},
+			"host_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("DBT_CLOUD_HOST_URL", "https://cloud.getdbt.com"),
+				Description: "The base url of the DBT cloud instance, e.g. https://emea.dbt.com for EMEA accounts. Can also be set with the DBT_CLOUD_HOST_URL environment variable. Defaults to https://cloud.getdbt.com",
+			},
+			"max_retries": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          4,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "How many times a request is retried when DBT throttles it (429) or is temporarily unavailable (502, 503, 504). Defaults to 4",
+			},
+			"retry_wait_min": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          1,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "Seconds to wait before the first retry. The wait is doubled for every following retry. Defaults to 1",
+			},
+			"retry_wait_max": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          30,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "The maximum number of seconds to wait between retries, unless DBT asks for a longer wait with a Retry-After header. Defaults to 30",
 			},
 		},
 		ConfigureContextFunc: providerConfigure,
@@ -31,13 +84,58 @@ func Provider() *schema.Provider {
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
 	serviceToken := d.Get("service_token").(string)
 	accountId := d.Get("account_id").(int)
+	hostUrl := strings.TrimSuffix(d.Get("host_url").(string), "/")
+	maxRetries := d.Get("max_retries").(int)
+	retryWaitMin := time.Duration(d.Get("retry_wait_min").(int)) * time.Second
+	retryWaitMax := time.Duration(d.Get("retry_wait_max").(int)) * time.Second
+
+	if serviceToken == "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Missing DBT service token",
+			Detail:   "The provider needs a service token. Set the service_token argument or the DBT_CLOUD_TOKEN environment variable",
+		})
+	}
+
+	if accountId == 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Missing DBT account id",
+			Detail:   "The provider needs an account id. Set the account_id argument or the DBT_CLOUD_ACCOUNT_ID environment variable",
+		})
+	}
+
+	if retryWaitMin > retryWaitMax {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid retry settings",
+			Detail:   "retry_wait_min can not be larger than retry_wait_max",
+		})
+	}
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	providerInput := &DbtProviderInput{serviceToken, accountId, hostUrl, maxRetries, retryWaitMin, retryWaitMax}
+
+	c := client.NewClient(providerInput.HostUrl, providerInput.ServiceToken, providerInput.AccountId)
+	c.MaxRetries = providerInput.MaxRetries
+	c.RetryWaitMin = providerInput.RetryWaitMin
+	c.RetryWaitMax = providerInput.RetryWaitMax
 
-	return &DbtProviderInput{serviceToken, accountId}, nil
+	return c, nil
 }
 
 type DbtProviderInput struct {
 	ServiceToken string
 	AccountId    int
+	HostUrl      string
+	MaxRetries   int
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
 }
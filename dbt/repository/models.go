@@ -0,0 +1,22 @@
+package dbtrepository
+
+type Repository struct {
+	Id                                    int        `json:"id,omitempty"`
+	AccountId                             int        `json:"account_id"`
+	ProjectId                             int        `json:"project_id"`
+	RemoteUrl                             string     `json:"remote_url"`
+	GitCloneStrategy                      string     `json:"git_clone_strategy"`
+	GithubInstallationId                  *int       `json:"github_installation_id"`
+	GitlabProjectId                       *int       `json:"gitlab_project_id"`
+	AzureActiveDirectoryProjectId         *string    `json:"azure_active_directory_project_id"`
+	AzureActiveDirectoryRepositoryId      *string    `json:"azure_active_directory_repository_id"`
+	AzureBypassWebhookRegistrationFailure bool       `json:"azure_bypass_webhook_registration_failure"`
+	PullRequestUrlTemplate                *string    `json:"pull_request_url_template"`
+	State                                 int        `json:"state,omitempty"`
+	DeployKey                             *DeployKey `json:"deploy_key,omitempty"`
+}
+
+// DeployKey is generated by DBT for repositories using the deploy_key clone strategy.
+type DeployKey struct {
+	PublicKey string `json:"public_key"`
+}
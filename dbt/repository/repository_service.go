@@ -0,0 +1,78 @@
+package dbtrepository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"terraform-provider-dbt/dbt/client"
+)
+
+func CreateRepository(ctx context.Context, c *client.Client, repositoryInput *Repository) (*Repository, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/repositories/", repositoryInput.AccountId, repositoryInput.ProjectId)
+
+	repository, err := client.Post[Repository](ctx, c, path, repositoryInput)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in CreateRepository",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return repository, nil
+}
+
+func UpdateRepository(ctx context.Context, c *client.Client, repositoryInput *Repository) (*Repository, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/repositories/%d/", repositoryInput.AccountId, repositoryInput.ProjectId, repositoryInput.Id)
+
+	repository, err := client.Post[Repository](ctx, c, path, repositoryInput)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in UpdateRepository",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return repository, nil
+}
+
+func ReadRepository(ctx context.Context, c *client.Client, accountId int, projectId int, repositoryId int) (*Repository, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/repositories/%d/", accountId, projectId, repositoryId)
+
+	repository, err := client.Get[Repository](ctx, c, path)
+	if client.IsNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in ReadRepository",
+			Detail:   err.Error(),
+		}}
+	}
+
+	if repository.State == client.StateDeleted {
+		return nil, nil
+	}
+
+	return repository, nil
+}
+
+func DeleteRepository(ctx context.Context, c *client.Client, accountId int, projectId int, repositoryId int) diag.Diagnostics {
+	path := fmt.Sprintf("/api/v3/accounts/%d/projects/%d/repositories/%d/", accountId, projectId, repositoryId)
+
+	err := c.Delete(ctx, path)
+	if err != nil && !client.IsNotFound(err) {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in DeleteRepository",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package dbt
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	dbtcredential "terraform-provider-dbt/dbt/credential"
+)
+
+// resourceBigQueryCredential has no secrets, BigQuery authenticates with the service account of the connection.
+func resourceBigQueryCredential() *schema.Resource {
+	return resourceCredential(credentialAdapter{
+		toCredential: func(d *schema.ResourceData, credential *dbtcredential.Credential) {
+			credential.Type = "bigquery"
+			credential.Dataset = d.Get("dataset").(string)
+		},
+		setState: func(d *schema.ResourceData, credential *dbtcredential.Credential) {
+			d.Set("dataset", credential.Dataset)
+		},
+	}, map[string]*schema.Schema{
+		"dataset": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The dataset dbt builds models in",
+		},
+	})
+}
@@ -0,0 +1,524 @@
+package dbt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"terraform-provider-dbt/dbt/client"
+	dbtconnection "terraform-provider-dbt/dbt/connection"
+	utils "terraform-provider-dbt/dbt/utils"
+)
+
+// connectionAdapter maps one of the adapter blocks of dbt_connection to the connection details used by DBT.
+// Secrets are never returned by DBT, so fromDetails gets the current block to keep them from state.
+type connectionAdapter struct {
+	block          string
+	connectionType string
+	schema         map[string]*schema.Schema
+	toDetails      func(block map[string]interface{}) (map[string]interface{}, error)
+	fromDetails    func(details map[string]interface{}, current map[string]interface{}) map[string]interface{}
+	matches        func(connection *dbtconnection.Connection) bool
+}
+
+var connectionAdapters = []connectionAdapter{
+	{
+		block:          "snowflake",
+		connectionType: "snowflake",
+		schema: map[string]*schema.Schema{
+			"account": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The Snowflake account identifier",
+			},
+			"database": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The default database",
+			},
+			"warehouse": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The default warehouse",
+			},
+			"role": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The default role",
+			},
+			"allow_sso": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow developers to authenticate with Snowflake OAuth",
+			},
+			"client_session_keep_alive": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Keep the Snowflake session alive between queries",
+			},
+			"oauth_client_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The OAuth client id, used when allow_sso is true",
+			},
+			"oauth_client_secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The OAuth client secret, used when allow_sso is true. DBT never returns it, so changes made outside of terraform are not detected",
+			},
+		},
+		toDetails: func(block map[string]interface{}) (map[string]interface{}, error) {
+			return map[string]interface{}{
+				"account":                   block["account"],
+				"database":                  block["database"],
+				"warehouse":                 block["warehouse"],
+				"role":                      block["role"],
+				"allow_sso":                 block["allow_sso"],
+				"client_session_keep_alive": block["client_session_keep_alive"],
+				"oauth_client_id":           block["oauth_client_id"],
+				"oauth_client_secret":       block["oauth_client_secret"],
+			}, nil
+		},
+		fromDetails: func(details map[string]interface{}, current map[string]interface{}) map[string]interface{} {
+			return map[string]interface{}{
+				"account":                   detailString(details, "account"),
+				"database":                  detailString(details, "database"),
+				"warehouse":                 detailString(details, "warehouse"),
+				"role":                      detailString(details, "role"),
+				"allow_sso":                 detailBool(details, "allow_sso"),
+				"client_session_keep_alive": detailBool(details, "client_session_keep_alive"),
+				"oauth_client_id":           current["oauth_client_id"],
+				"oauth_client_secret":       current["oauth_client_secret"],
+			}
+		},
+	},
+	{
+		block:          "bigquery",
+		connectionType: "bigquery",
+		schema: map[string]*schema.Schema{
+			"gcp_project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The GCP project the queries run in",
+			},
+			"location": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The location of the datasets, e.g. EU or US",
+			},
+			"timeout_seconds": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     300,
+				Description: "Query timeout in seconds. Defaults to 300",
+			},
+			"retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1,
+				Description: "How many times a failed query is retried. Defaults to 1",
+			},
+			"maximum_bytes_billed": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Queries billing more bytes than this fail",
+			},
+			"service_account_json": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Sensitive:        true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+				Description:      "The json key file of the service account, e.g. file(\"service-account.json\"). DBT never returns the private key, so changes made outside of terraform are only detected through client_email",
+			},
+			"client_email": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The email of the service account used by the connection",
+			},
+		},
+		toDetails: func(block map[string]interface{}) (map[string]interface{}, error) {
+			var serviceAccount map[string]interface{}
+			err := json.Unmarshal([]byte(block["service_account_json"].(string)), &serviceAccount)
+			if err != nil {
+				return nil, fmt.Errorf("service_account_json is not valid json: %w", err)
+			}
+
+			details := map[string]interface{}{
+				"project_id":      block["gcp_project_id"],
+				"location":        block["location"],
+				"timeout_seconds": block["timeout_seconds"],
+				"retries":         block["retries"],
+			}
+			if block["maximum_bytes_billed"].(int) != 0 {
+				details["maximum_bytes_billed"] = block["maximum_bytes_billed"]
+			}
+
+			for _, key := range []string{"private_key_id", "private_key", "client_email", "client_id", "auth_uri", "token_uri", "auth_provider_x509_cert_url", "client_x509_cert_url"} {
+				details[key] = serviceAccount[key]
+			}
+
+			return details, nil
+		},
+		fromDetails: func(details map[string]interface{}, current map[string]interface{}) map[string]interface{} {
+			serviceAccountJson := current["service_account_json"]
+
+			// The only part of the key DBT returns is the service account, a change there means the key was replaced.
+			if current["client_email"] != nil && current["client_email"] != "" && current["client_email"] != detailString(details, "client_email") {
+				serviceAccountJson = ""
+			}
+
+			return map[string]interface{}{
+				"gcp_project_id":       detailString(details, "project_id"),
+				"location":             detailString(details, "location"),
+				"timeout_seconds":      detailInt(details, "timeout_seconds"),
+				"retries":              detailInt(details, "retries"),
+				"maximum_bytes_billed": detailInt(details, "maximum_bytes_billed"),
+				"service_account_json": serviceAccountJson,
+				"client_email":         detailString(details, "client_email"),
+			}
+		},
+	},
+	{
+		block:          "databricks",
+		connectionType: "adapter",
+		schema: map[string]*schema.Schema{
+			"host": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The hostname of the Databricks workspace",
+			},
+			"http_path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The http path of the SQL warehouse or cluster",
+			},
+			"catalog": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The Unity Catalog to use",
+			},
+			"adapter_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Id of the adapter of the connection, used by dbt_databricks_credential",
+			},
+		},
+		toDetails: func(block map[string]interface{}) (map[string]interface{}, error) {
+			return map[string]interface{}{
+				"adapter_details": map[string]interface{}{
+					"fields": map[string]interface{}{
+						"type":      map[string]interface{}{"value": "databricks"},
+						"host":      map[string]interface{}{"value": block["host"]},
+						"http_path": map[string]interface{}{"value": block["http_path"]},
+						"catalog":   map[string]interface{}{"value": block["catalog"]},
+					},
+				},
+			}, nil
+		},
+		fromDetails: func(details map[string]interface{}, current map[string]interface{}) map[string]interface{} {
+			return map[string]interface{}{
+				"host":      adapterField(details, "host"),
+				"http_path": adapterField(details, "http_path"),
+				"catalog":   adapterField(details, "catalog"),
+			}
+		},
+		matches: func(connection *dbtconnection.Connection) bool {
+			return connection.Type == "adapter" && adapterField(connection.Details, "type") == "databricks"
+		},
+	},
+	{
+		block:          "redshift",
+		connectionType: "redshift",
+		schema:         postgresConnectionSchema(5439),
+		toDetails:      postgresConnectionDetails,
+		fromDetails:    postgresConnectionFromDetails,
+	},
+	{
+		block:          "postgres",
+		connectionType: "postgres",
+		schema:         postgresConnectionSchema(5432),
+		toDetails:      postgresConnectionDetails,
+		fromDetails:    postgresConnectionFromDetails,
+	},
+}
+
+// postgresConnectionSchema is shared by Postgres and Redshift, which only differ in the default port.
+func postgresConnectionSchema(defaultPort int) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"hostname": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The hostname of the database",
+		},
+		"port": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     defaultPort,
+			Description: fmt.Sprintf("The port of the database. Defaults to %d", defaultPort),
+		},
+		"dbname": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The name of the database",
+		},
+	}
+}
+
+func postgresConnectionDetails(block map[string]interface{}) (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"hostname": block["hostname"],
+		"port":     block["port"],
+		"dbname":   block["dbname"],
+	}, nil
+}
+
+func postgresConnectionFromDetails(details map[string]interface{}, current map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"hostname": detailString(details, "hostname"),
+		"port":     detailInt(details, "port"),
+		"dbname":   detailString(details, "dbname"),
+	}
+}
+
+func resourceConnection() *schema.Resource {
+	connectionSchema := map[string]*schema.Schema{
+		"project_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Id of the project the connection belongs to",
+		},
+		"connection_id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The numeric id of the connection in DBT cloud",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of the connection",
+		},
+	}
+
+	var adapterBlocks []string
+	for _, adapter := range connectionAdapters {
+		adapterBlocks = append(adapterBlocks, adapter.block)
+	}
+
+	for _, adapter := range connectionAdapters {
+		connectionSchema[adapter.block] = &schema.Schema{
+			Type:         schema.TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: adapterBlocks,
+			Description:  fmt.Sprintf("Settings for a %s connection", adapter.block),
+			Elem: &schema.Resource{
+				Schema: adapter.schema,
+			},
+		}
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceConnectionCreate,
+		ReadContext:   resourceConnectionRead,
+		UpdateContext: resourceConnectionUpdate,
+		DeleteContext: resourceConnectionDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema:        connectionSchema,
+		CustomizeDiff: resourceConnectionCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// resourceConnectionCustomizeDiff replaces the connection when it is switched to another adapter.
+func resourceConnectionCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	for _, adapter := range connectionAdapters {
+		old, new := d.GetChange(adapter.block)
+		if len(old.([]interface{})) != len(new.([]interface{})) {
+			return d.ForceNew(adapter.block)
+		}
+	}
+
+	return nil
+}
+
+func resourceConnectionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	connectionInput, err := readConnectionFromResourceData(d, c.AccountId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	connection, diags := dbtconnection.CreateConnection(ctx, c, connectionInput)
+	if diags != nil {
+		return diags
+	}
+
+	setStateFromConnection(d, connection)
+
+	return nil
+}
+
+func resourceConnectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	projectId, connectionId, err := utils.ParseProjectScopedId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	connection, diags := dbtconnection.ReadConnection(ctx, c, c.AccountId, projectId, connectionId)
+	if diags != nil {
+		return diags
+	}
+
+	if connection != nil {
+		setStateFromConnection(d, connection)
+	} else {
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceConnectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	connectionInput, err := readConnectionFromResourceData(d, c.AccountId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	connection, diags := dbtconnection.UpdateConnection(ctx, c, connectionInput)
+	if diags != nil {
+		return diags
+	}
+
+	setStateFromConnection(d, connection)
+
+	return nil
+}
+
+func resourceConnectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	projectId, connectionId, err := utils.ParseProjectScopedId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	diags := dbtconnection.DeleteConnection(ctx, c, c.AccountId, projectId, connectionId)
+
+	if diags == nil {
+		d.SetId("")
+	}
+
+	return diags
+}
+
+func setStateFromConnection(d *schema.ResourceData, connection *dbtconnection.Connection) {
+	d.SetId(utils.FormatProjectScopedId(connection.ProjectId, connection.Id))
+	d.Set("project_id", connection.ProjectId)
+	d.Set("connection_id", connection.Id)
+	d.Set("name", connection.Name)
+
+	for _, adapter := range connectionAdapters {
+		if !adapterMatches(adapter, connection) {
+			d.Set(adapter.block, nil)
+			continue
+		}
+
+		current := map[string]interface{}{}
+		if blocks := d.Get(adapter.block).([]interface{}); len(blocks) > 0 && blocks[0] != nil {
+			current = blocks[0].(map[string]interface{})
+		}
+
+		block := adapter.fromDetails(connection.Details, current)
+		if _, ok := adapter.schema["adapter_id"]; ok {
+			block["adapter_id"] = connection.AdapterId
+		}
+
+		d.Set(adapter.block, []interface{}{block})
+	}
+}
+
+func readConnectionFromResourceData(data *schema.ResourceData, accountId int) (*dbtconnection.Connection, error) {
+	connection := &dbtconnection.Connection{
+		Id:        data.Get("connection_id").(int),
+		AccountId: accountId,
+		ProjectId: data.Get("project_id").(int),
+		Name:      data.Get("name").(string),
+		State:     client.StateActive,
+	}
+
+	for _, adapter := range connectionAdapters {
+		blocks := data.Get(adapter.block).([]interface{})
+		if len(blocks) == 0 || blocks[0] == nil {
+			continue
+		}
+
+		details, err := adapter.toDetails(blocks[0].(map[string]interface{}))
+		if err != nil {
+			return nil, err
+		}
+
+		connection.Type = adapter.connectionType
+		connection.Details = details
+	}
+
+	return connection, nil
+}
+
+func adapterMatches(adapter connectionAdapter, connection *dbtconnection.Connection) bool {
+	if adapter.matches != nil {
+		return adapter.matches(connection)
+	}
+
+	return connection.Type == adapter.connectionType
+}
+
+func detailString(details map[string]interface{}, key string) string {
+	value, _ := details[key].(string)
+	return value
+}
+
+// detailInt reads a number from the details, which are decoded from json as float64.
+func detailInt(details map[string]interface{}, key string) int {
+	value, _ := details[key].(float64)
+	return int(value)
+}
+
+func detailBool(details map[string]interface{}, key string) bool {
+	value, _ := details[key].(bool)
+	return value
+}
+
+// adapterField reads a field of a connection using the generic dbt adapter format, where every field is {"value": ...}.
+func adapterField(details map[string]interface{}, key string) string {
+	adapterDetails, _ := details["adapter_details"].(map[string]interface{})
+	fields, _ := adapterDetails["fields"].(map[string]interface{})
+	field, _ := fields[key].(map[string]interface{})
+	value, _ := field["value"].(string)
+	return value
+}
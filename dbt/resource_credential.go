@@ -0,0 +1,158 @@
+package dbt
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"terraform-provider-dbt/dbt/client"
+	dbtcredential "terraform-provider-dbt/dbt/credential"
+	utils "terraform-provider-dbt/dbt/utils"
+)
+
+// credentialAdapter is implemented by the per-adapter credential resources. DBT never returns secrets, so setState must
+// leave them untouched and the state keeps the values last written.
+type credentialAdapter struct {
+	toCredential func(d *schema.ResourceData, credential *dbtcredential.Credential)
+	setState     func(d *schema.ResourceData, credential *dbtcredential.Credential)
+}
+
+// resourceCredential builds a credential resource from the attributes of one adapter.
+func resourceCredential(adapter credentialAdapter, attributes map[string]*schema.Schema) *schema.Resource {
+	credentialSchema := map[string]*schema.Schema{
+		"project_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Id of the project the credential belongs to",
+		},
+		"credential_id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The numeric id of the credential in DBT cloud, used as credential_id of dbt_environment",
+		},
+		"num_threads": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Default:     4,
+			Description: "The number of threads dbt runs with. Defaults to 4",
+		},
+	}
+
+	for name, attribute := range attributes {
+		credentialSchema[name] = attribute
+	}
+
+	return &schema.Resource{
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return resourceCredentialCreate(ctx, d, m, adapter)
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return resourceCredentialRead(ctx, d, m, adapter)
+		},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return resourceCredentialUpdate(ctx, d, m, adapter)
+		},
+		DeleteContext: resourceCredentialDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: credentialSchema,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceCredentialCreate(ctx context.Context, d *schema.ResourceData, m interface{}, adapter credentialAdapter) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	credential, diags := dbtcredential.CreateCredential(ctx, c, readCredentialFromResourceData(d, c.AccountId, adapter))
+	if diags != nil {
+		return diags
+	}
+
+	setStateFromCredential(d, credential, adapter)
+
+	return nil
+}
+
+func resourceCredentialRead(ctx context.Context, d *schema.ResourceData, m interface{}, adapter credentialAdapter) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	projectId, credentialId, err := utils.ParseProjectScopedId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	credential, diags := dbtcredential.ReadCredential(ctx, c, c.AccountId, projectId, credentialId)
+	if diags != nil {
+		return diags
+	}
+
+	if credential != nil {
+		setStateFromCredential(d, credential, adapter)
+	} else {
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceCredentialUpdate(ctx context.Context, d *schema.ResourceData, m interface{}, adapter credentialAdapter) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	credential, diags := dbtcredential.UpdateCredential(ctx, c, readCredentialFromResourceData(d, c.AccountId, adapter))
+	if diags != nil {
+		return diags
+	}
+
+	setStateFromCredential(d, credential, adapter)
+
+	return nil
+}
+
+func resourceCredentialDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	projectId, credentialId, err := utils.ParseProjectScopedId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	diags := dbtcredential.DeleteCredential(ctx, c, c.AccountId, projectId, credentialId)
+
+	if diags == nil {
+		d.SetId("")
+	}
+
+	return diags
+}
+
+func setStateFromCredential(d *schema.ResourceData, credential *dbtcredential.Credential, adapter credentialAdapter) {
+	d.SetId(utils.FormatProjectScopedId(credential.ProjectId, credential.Id))
+	d.Set("project_id", credential.ProjectId)
+	d.Set("credential_id", credential.Id)
+	d.Set("num_threads", credential.Threads)
+	adapter.setState(d, credential)
+}
+
+func readCredentialFromResourceData(data *schema.ResourceData, accountId int, adapter credentialAdapter) *dbtcredential.Credential {
+	credential := &dbtcredential.Credential{
+		Id:         data.Get("credential_id").(int),
+		AccountId:  accountId,
+		ProjectId:  data.Get("project_id").(int),
+		State:      client.StateActive,
+		Threads:    data.Get("num_threads").(int),
+		TargetName: "default",
+	}
+
+	adapter.toCredential(data, credential)
+
+	return credential
+}
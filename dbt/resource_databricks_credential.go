@@ -0,0 +1,59 @@
+package dbt
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	dbtcredential "terraform-provider-dbt/dbt/credential"
+)
+
+// resourceDatabricksCredential uses the generic dbt adapter format, where every field is {"value": ...}.
+func resourceDatabricksCredential() *schema.Resource {
+	return resourceCredential(credentialAdapter{
+		toCredential: func(d *schema.ResourceData, credential *dbtcredential.Credential) {
+			credential.Type = "adapter"
+			credential.AdapterId = d.Get("adapter_id").(int)
+			credential.CredentialDetails = map[string]interface{}{
+				"fields": map[string]interface{}{
+					"schema":  map[string]interface{}{"value": d.Get("schema")},
+					"catalog": map[string]interface{}{"value": d.Get("catalog")},
+					"token":   map[string]interface{}{"value": d.Get("token")},
+				},
+			}
+		},
+		setState: func(d *schema.ResourceData, credential *dbtcredential.Credential) {
+			d.Set("adapter_id", credential.AdapterId)
+			d.Set("schema", credentialField(credential, "schema"))
+			d.Set("catalog", credentialField(credential, "catalog"))
+		},
+	}, map[string]*schema.Schema{
+		"adapter_id": {
+			Type:        schema.TypeInt,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Id of the adapter of the Databricks connection, see adapter_id of the databricks block of dbt_connection",
+		},
+		"schema": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The schema dbt builds models in",
+		},
+		"catalog": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The Unity Catalog dbt builds models in. Uses the catalog of the connection if not set",
+		},
+		"token": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Sensitive:   true,
+			Description: "The Databricks personal access token dbt runs with. DBT never returns it, so it is kept in the terraform state as it was last written",
+		},
+	})
+}
+
+func credentialField(credential *dbtcredential.Credential, key string) string {
+	fields, _ := credential.CredentialDetails["fields"].(map[string]interface{})
+	field, _ := fields[key].(map[string]interface{})
+	value, _ := field["value"].(string)
+	return value
+}
@@ -0,0 +1,189 @@
+package dbt
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"terraform-provider-dbt/dbt/client"
+	dbtenvironment "terraform-provider-dbt/dbt/environment"
+	utils "terraform-provider-dbt/dbt/utils"
+)
+
+func resourceEnvironment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceEnvironmentCreate,
+		ReadContext:   resourceEnvironmentRead,
+		UpdateContext: resourceEnvironmentUpdate,
+		DeleteContext: resourceEnvironmentDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Id of the project the environment belongs to",
+			},
+			"environment_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The numeric id of the environment in DBT cloud",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the environment",
+			},
+			"type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"development", "deployment"}, false)),
+				Description:      "Either development or deployment",
+			},
+			"deployment_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"production", "staging"}, false)),
+				Description:      "Either production or staging. Only valid for deployment environments",
+			},
+			"dbt_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The dbt version used in the environment. Uses the DBT cloud default if not set",
+			},
+			"custom_branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The git branch to use when use_custom_branch is true",
+			},
+			"use_custom_branch": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Set this to true to run the environment on custom_branch instead of the default branch",
+			},
+			"credential_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Id of the warehouse credentials used by a deployment environment",
+			},
+			"connection_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Id of the warehouse connection used by the environment. Uses the project connection if not set",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceEnvironmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+	environmentInput := readEnvironmentFromResourceData(d, c.AccountId)
+
+	environment, diags := dbtenvironment.CreateEnvironment(ctx, c, environmentInput)
+	if diags != nil {
+		return diags
+	}
+
+	setStateFromEnvironment(d, environment)
+
+	return nil
+}
+
+func resourceEnvironmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	projectId, environmentId, err := utils.ParseProjectScopedId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	environment, diags := dbtenvironment.ReadEnvironment(ctx, c, c.AccountId, projectId, environmentId)
+	if diags != nil {
+		return diags
+	}
+
+	if environment != nil {
+		setStateFromEnvironment(d, environment)
+	} else {
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceEnvironmentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+	environmentInput := readEnvironmentFromResourceData(d, c.AccountId)
+
+	environment, diags := dbtenvironment.UpdateEnvironment(ctx, c, environmentInput)
+	if diags != nil {
+		return diags
+	}
+
+	setStateFromEnvironment(d, environment)
+
+	return nil
+}
+
+func resourceEnvironmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	projectId, environmentId, err := utils.ParseProjectScopedId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	diags := dbtenvironment.DeleteEnvironment(ctx, c, c.AccountId, projectId, environmentId)
+
+	if diags == nil {
+		d.SetId("")
+	}
+
+	return diags
+}
+
+func setStateFromEnvironment(d *schema.ResourceData, environment *dbtenvironment.Environment) {
+	d.SetId(utils.FormatProjectScopedId(environment.ProjectId, environment.Id))
+	d.Set("project_id", environment.ProjectId)
+	d.Set("environment_id", environment.Id)
+	d.Set("name", environment.Name)
+	d.Set("type", environment.Type)
+	d.Set("deployment_type", utils.PointerToString(environment.DeploymentType))
+	d.Set("dbt_version", environment.DbtVersion)
+	d.Set("custom_branch", utils.PointerToString(environment.CustomBranch))
+	d.Set("use_custom_branch", environment.UseCustomBranch)
+	d.Set("credential_id", utils.PointerToInt(environment.CredentialId))
+	d.Set("connection_id", utils.PointerToInt(environment.ConnectionId))
+}
+
+func readEnvironmentFromResourceData(data *schema.ResourceData, accountId int) *dbtenvironment.Environment {
+	environment := &dbtenvironment.Environment{
+		Id:              data.Get("environment_id").(int),
+		AccountId:       accountId,
+		ProjectId:       data.Get("project_id").(int),
+		Name:            data.Get("name").(string),
+		Type:            data.Get("type").(string),
+		DeploymentType:  utils.StringToPointer(data.Get("deployment_type").(string)),
+		DbtVersion:      data.Get("dbt_version").(string),
+		CustomBranch:    utils.StringToPointer(data.Get("custom_branch").(string)),
+		UseCustomBranch: data.Get("use_custom_branch").(bool),
+		CredentialId:    utils.IntToPointer(data.Get("credential_id").(int)),
+		ConnectionId:    utils.IntToPointer(data.Get("connection_id").(int)),
+	}
+
+	return environment
+}
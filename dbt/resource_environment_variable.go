@@ -0,0 +1,210 @@
+package dbt
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"terraform-provider-dbt/dbt/client"
+	dbtenvironmentvariable "terraform-provider-dbt/dbt/environment_variable"
+)
+
+var validateEnvironmentVariableName = validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile("^DBT_"), "must start with DBT_"))
+
+func resourceEnvironmentVariable() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceEnvironmentVariableCreate,
+		ReadContext:   resourceEnvironmentVariableRead,
+		UpdateContext: resourceEnvironmentVariableUpdate,
+		DeleteContext: resourceEnvironmentVariableDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Id of the project the environment variable belongs to",
+			},
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateEnvironmentVariableName,
+				Description:      "Name of the environment variable, must start with DBT_. Variables starting with DBT_ENV_SECRET_ are secret",
+			},
+			"environment_values": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ExactlyOneOf: []string{"environment_values", "secret_environment_values"},
+				Description:  "The values of the variable keyed by environment name, with project as the key of the project default. Values set outside of terraform are removed. Not allowed for secret variables",
+			},
+			"secret_environment_values": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Sensitive:    true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ExactlyOneOf: []string{"environment_values", "secret_environment_values"},
+				Description:  "The values of a DBT_ENV_SECRET_ variable, keyed like environment_values. DBT never returns them, so they are kept in the terraform state as they were last written, and only environments added or removed outside of terraform are detected",
+			},
+		},
+		CustomizeDiff: resourceEnvironmentVariableCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceEnvironmentVariableImport,
+		},
+	}
+}
+
+func resourceEnvironmentVariableCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	return validateEnvironmentVariableSecret(d, "environment_values", "secret_environment_values")
+}
+
+// validateEnvironmentVariableSecret checks that the values of secret variables are set in secretAttribute, and the
+// values of other variables in attribute.
+func validateEnvironmentVariableSecret(d *schema.ResourceDiff, attribute string, secretAttribute string) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	name := config.GetAttr("name")
+	if name.IsNull() || !name.IsKnown() || name.Type() != cty.String {
+		return nil
+	}
+
+	if strings.HasPrefix(name.AsString(), dbtenvironmentvariable.SecretPrefix) {
+		if !config.GetAttr(attribute).IsNull() {
+			return fmt.Errorf("%s is a secret variable, use %s instead of %s", name.AsString(), secretAttribute, attribute)
+		}
+	} else if !config.GetAttr(secretAttribute).IsNull() {
+		return fmt.Errorf("%s can only be used for variables starting with %s, use %s for %s", secretAttribute, dbtenvironmentvariable.SecretPrefix, attribute, name.AsString())
+	}
+
+	return nil
+}
+
+func resourceEnvironmentVariableImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected project_id:name", d.Id())
+	}
+
+	projectId, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("unexpected format of ID (%s), project_id must be a number", d.Id())
+	}
+
+	d.Set("project_id", projectId)
+	d.Set("name", parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceEnvironmentVariableCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	environmentVariable := readEnvironmentVariableFromResourceData(d, c.AccountId)
+
+	diags := dbtenvironmentvariable.CreateEnvironmentVariable(ctx, c, environmentVariable)
+	if diags != nil {
+		return diags
+	}
+
+	d.SetId(fmt.Sprintf("%d:%s", environmentVariable.ProjectId, environmentVariable.Name))
+
+	return resourceEnvironmentVariableRead(ctx, d, m)
+}
+
+func resourceEnvironmentVariableRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	projectId := d.Get("project_id").(int)
+	name := d.Get("name").(string)
+
+	values, diags := dbtenvironmentvariable.ReadEnvironmentVariable(ctx, c, c.AccountId, projectId, name)
+	if diags != nil {
+		return diags
+	}
+
+	if values == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(fmt.Sprintf("%d:%s", projectId, name))
+
+	if strings.HasPrefix(name, dbtenvironmentvariable.SecretPrefix) {
+		current := d.Get("secret_environment_values").(map[string]interface{})
+		environmentValues := map[string]interface{}{}
+		for environment := range values {
+			environmentValues[environment] = current[environment]
+			if environmentValues[environment] == nil {
+				environmentValues[environment] = ""
+			}
+		}
+		d.Set("secret_environment_values", environmentValues)
+	} else {
+		environmentValues := map[string]interface{}{}
+		for environment, value := range values {
+			environmentValues[environment] = value.Value
+		}
+		d.Set("environment_values", environmentValues)
+	}
+
+	return nil
+}
+
+func resourceEnvironmentVariableUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	diags := dbtenvironmentvariable.UpdateEnvironmentVariable(ctx, c, readEnvironmentVariableFromResourceData(d, c.AccountId))
+	if diags != nil {
+		return diags
+	}
+
+	return resourceEnvironmentVariableRead(ctx, d, m)
+}
+
+func resourceEnvironmentVariableDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	diags := dbtenvironmentvariable.DeleteEnvironmentVariable(ctx, c, c.AccountId, d.Get("project_id").(int), d.Get("name").(string))
+
+	if diags == nil {
+		d.SetId("")
+	}
+
+	return diags
+}
+
+func readEnvironmentVariableFromResourceData(data *schema.ResourceData, accountId int) *dbtenvironmentvariable.EnvironmentVariable {
+	values := data.Get("environment_values").(map[string]interface{})
+	if secretValues := data.Get("secret_environment_values").(map[string]interface{}); len(secretValues) > 0 {
+		values = secretValues
+	}
+
+	environmentValues := map[string]string{}
+	for environment, value := range values {
+		environmentValues[environment] = value.(string)
+	}
+
+	return &dbtenvironmentvariable.EnvironmentVariable{
+		AccountId:         accountId,
+		ProjectId:         data.Get("project_id").(int),
+		Name:              data.Get("name").(string),
+		EnvironmentValues: environmentValues,
+	}
+}
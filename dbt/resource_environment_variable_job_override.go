@@ -0,0 +1,171 @@
+package dbt
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"terraform-provider-dbt/dbt/client"
+	dbtenvironmentvariable "terraform-provider-dbt/dbt/environment_variable"
+	utils "terraform-provider-dbt/dbt/utils"
+)
+
+func resourceEnvironmentVariableJobOverride() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceEnvironmentVariableJobOverrideCreate,
+		ReadContext:   resourceEnvironmentVariableJobOverrideRead,
+		UpdateContext: resourceEnvironmentVariableJobOverrideUpdate,
+		DeleteContext: resourceEnvironmentVariableJobOverrideDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Id of the project the job belongs to",
+			},
+			"job_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Id of the job the override applies to",
+			},
+			"override_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The numeric id of the override in DBT cloud",
+			},
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateEnvironmentVariableName,
+				Description:      "Name of the environment variable to override, must start with DBT_",
+			},
+			"raw_value": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"raw_value", "secret_raw_value"},
+				Description:  "The value of the variable in the job. Not allowed for secret variables",
+			},
+			"secret_raw_value": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"raw_value", "secret_raw_value"},
+				Description:  "The value of a DBT_ENV_SECRET_ variable in the job. DBT never returns it, so it is kept in the terraform state as it was last written",
+			},
+		},
+		CustomizeDiff: resourceEnvironmentVariableJobOverrideCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceEnvironmentVariableJobOverrideCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	return validateEnvironmentVariableSecret(d, "raw_value", "secret_raw_value")
+}
+
+func resourceEnvironmentVariableJobOverrideCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	jobOverride, diags := dbtenvironmentvariable.CreateJobOverride(ctx, c, readJobOverrideFromResourceData(d, c.AccountId))
+	if diags != nil {
+		return diags
+	}
+
+	setStateFromJobOverride(d, jobOverride)
+
+	return nil
+}
+
+func resourceEnvironmentVariableJobOverrideRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	projectId, jobOverrideId, err := utils.ParseProjectScopedId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	jobOverride, diags := dbtenvironmentvariable.ReadJobOverride(ctx, c, c.AccountId, projectId, jobOverrideId)
+	if diags != nil {
+		return diags
+	}
+
+	if jobOverride != nil {
+		setStateFromJobOverride(d, jobOverride)
+	} else {
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceEnvironmentVariableJobOverrideUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	jobOverride, diags := dbtenvironmentvariable.UpdateJobOverride(ctx, c, readJobOverrideFromResourceData(d, c.AccountId))
+	if diags != nil {
+		return diags
+	}
+
+	setStateFromJobOverride(d, jobOverride)
+
+	return nil
+}
+
+func resourceEnvironmentVariableJobOverrideDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	projectId, jobOverrideId, err := utils.ParseProjectScopedId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	diags := dbtenvironmentvariable.DeleteJobOverride(ctx, c, c.AccountId, projectId, jobOverrideId)
+
+	if diags == nil {
+		d.SetId("")
+	}
+
+	return diags
+}
+
+func setStateFromJobOverride(d *schema.ResourceData, jobOverride *dbtenvironmentvariable.JobOverride) {
+	d.SetId(utils.FormatProjectScopedId(jobOverride.ProjectId, jobOverride.Id))
+	d.Set("project_id", jobOverride.ProjectId)
+	d.Set("job_id", jobOverride.JobDefinitionId)
+	d.Set("override_id", jobOverride.Id)
+	d.Set("name", jobOverride.Name)
+
+	// DBT masks the values of secret variables, so secret_raw_value is kept from the configuration.
+	if !strings.HasPrefix(jobOverride.Name, dbtenvironmentvariable.SecretPrefix) {
+		d.Set("raw_value", jobOverride.RawValue)
+	}
+}
+
+func readJobOverrideFromResourceData(data *schema.ResourceData, accountId int) *dbtenvironmentvariable.JobOverride {
+	rawValue := data.Get("raw_value").(string)
+	if strings.HasPrefix(data.Get("name").(string), dbtenvironmentvariable.SecretPrefix) {
+		rawValue = data.Get("secret_raw_value").(string)
+	}
+
+	return &dbtenvironmentvariable.JobOverride{
+		Id:              data.Get("override_id").(int),
+		AccountId:       accountId,
+		ProjectId:       data.Get("project_id").(int),
+		JobDefinitionId: data.Get("job_id").(int),
+		Name:            data.Get("name").(string),
+		Type:            "job",
+		RawValue:        rawValue,
+	}
+}
@@ -0,0 +1,470 @@
+package dbt
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"terraform-provider-dbt/dbt/client"
+	dbtjob "terraform-provider-dbt/dbt/job"
+	utils "terraform-provider-dbt/dbt/utils"
+)
+
+// jobRunStatuses maps the run statuses used in job_completion_trigger_condition to the codes used by DBT.
+var jobRunStatuses = map[string]int{
+	"success":  10,
+	"error":    20,
+	"canceled": 30,
+}
+
+func resourceJob() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceJobCreate,
+		ReadContext:   resourceJobRead,
+		UpdateContext: resourceJobUpdate,
+		DeleteContext: resourceJobDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Id of the project the job belongs to",
+			},
+			"environment_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Id of the environment the job runs in",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the job",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the job",
+			},
+			"execute_steps": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The dbt commands the job runs, in order",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"dbt_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The dbt version used by the job. Uses the version of the environment if not set",
+			},
+			"triggers": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "What triggers the job to run",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"schedule": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Run the job on the schedule",
+						},
+						"github_webhook": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Run the job on pull requests in GitHub",
+						},
+						"git_provider_webhook": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Run the job on pull requests in GitLab or Azure DevOps",
+						},
+						"on_merge": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Run the job when a pull request is merged",
+						},
+					},
+				},
+			},
+			"generate_docs": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Generate docs when the job runs",
+			},
+			"run_generate_sources": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Check source freshness when the job runs",
+			},
+			"target_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "default",
+				Description: "The dbt target name. Defaults to default",
+			},
+			"threads": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     4,
+				Description: "The number of threads dbt uses. Defaults to 4",
+			},
+			"timeout_seconds": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Cancel the run after this many seconds. 0 means no timeout",
+			},
+			"deferring_environment_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Id of the environment the job defers to",
+			},
+			"schedule_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "every_day",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"every_day", "days_of_week", "custom_cron"}, false)),
+				Description:      "One of every_day, days_of_week or custom_cron. Defaults to every_day",
+			},
+			"schedule_days": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The days of the week the job runs, 0 is Sunday. Used with schedule_type days_of_week",
+				Elem: &schema.Schema{
+					Type:             schema.TypeInt,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 6)),
+				},
+			},
+			"schedule_hours": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The hours (UTC) the job runs. Runs every schedule_interval hours if not set",
+				Elem: &schema.Schema{
+					Type:             schema.TypeInt,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 23)),
+				},
+			},
+			"schedule_interval": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          1,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 23)),
+				Description:      "Run the job every n hours, when schedule_hours is not set. Defaults to 1",
+			},
+			"schedule_cron": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The cron expression used with schedule_type custom_cron",
+			},
+			"job_completion_trigger_condition": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Run the job when another job completes",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"job_id": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "Id of the job to wait for",
+						},
+						"project_id": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "Id of the project of the job to wait for",
+						},
+						"statuses": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							Description: "The run statuses that trigger this job: success, error and/or canceled",
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"success", "error", "canceled"}, false)),
+							},
+						},
+					},
+				},
+			},
+		},
+		CustomizeDiff: resourceJobCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// resourceJobCustomizeDiff checks that the schedule attributes match schedule_type, so that an invalid
+// schedule fails at plan instead of being sent to DBT.
+func resourceJobCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || !d.NewValueKnown("schedule_type") {
+		return nil
+	}
+
+	scheduleType := d.Get("schedule_type").(string)
+	cron := config.GetAttr("schedule_cron")
+	days := config.GetAttr("schedule_days")
+
+	if scheduleType == "custom_cron" {
+		if cron.IsNull() || (cron.IsKnown() && strings.TrimSpace(cron.AsString()) == "") {
+			return fmt.Errorf("schedule_cron is required when schedule_type is custom_cron")
+		}
+
+		if cron.IsKnown() && len(strings.Fields(cron.AsString())) != 5 {
+			return fmt.Errorf("schedule_cron must be a cron expression with 5 fields, got %q", cron.AsString())
+		}
+	}
+
+	if scheduleType == "days_of_week" {
+		if days.IsNull() || (days.IsWhollyKnown() && days.LengthInt() == 0) {
+			return fmt.Errorf("schedule_days must contain at least one day when schedule_type is days_of_week")
+		}
+	}
+
+	return nil
+}
+
+func resourceJobCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+	jobInput := readJobFromResourceData(d, c.AccountId)
+
+	job, diags := dbtjob.CreateJob(ctx, c, jobInput)
+	if diags != nil {
+		return diags
+	}
+
+	setStateFromJob(d, job)
+
+	return nil
+}
+
+func resourceJobRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+	jobId, _ := strconv.Atoi(d.Id())
+
+	job, diags := dbtjob.ReadJob(ctx, c, c.AccountId, jobId)
+	if diags != nil {
+		return diags
+	}
+
+	if job != nil {
+		setStateFromJob(d, job)
+	} else {
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceJobUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+	jobInput := readJobFromResourceData(d, c.AccountId)
+
+	job, diags := dbtjob.UpdateJob(ctx, c, jobInput)
+	if diags != nil {
+		return diags
+	}
+
+	setStateFromJob(d, job)
+
+	return nil
+}
+
+func resourceJobDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+	jobId, _ := strconv.Atoi(d.Id())
+
+	diags := dbtjob.DeleteJob(ctx, c, c.AccountId, jobId)
+
+	if diags == nil {
+		d.SetId("")
+	}
+
+	return diags
+}
+
+func setStateFromJob(d *schema.ResourceData, job *dbtjob.Job) {
+	d.SetId(strconv.Itoa(job.Id))
+	d.Set("project_id", job.ProjectId)
+	d.Set("environment_id", job.EnvironmentId)
+	d.Set("name", job.Name)
+	d.Set("description", job.Description)
+	d.Set("execute_steps", job.ExecuteSteps)
+	d.Set("dbt_version", utils.PointerToString(job.DbtVersion))
+	d.Set("triggers", []interface{}{map[string]interface{}{
+		"schedule":             job.Triggers.Schedule,
+		"github_webhook":       job.Triggers.GithubWebhook,
+		"git_provider_webhook": job.Triggers.GitProviderWebhook,
+		"on_merge":             job.Triggers.OnMerge,
+	}})
+	d.Set("generate_docs", job.GenerateDocs)
+	d.Set("run_generate_sources", job.RunGenerateSources)
+	d.Set("target_name", job.Settings.TargetName)
+	d.Set("threads", job.Settings.Threads)
+	d.Set("timeout_seconds", job.TimeoutSeconds)
+	d.Set("deferring_environment_id", utils.PointerToInt(job.DeferringEnvironmentId))
+
+	d.Set("schedule_type", job.Schedule.Date.Type)
+	d.Set("schedule_days", job.Schedule.Date.Days)
+	d.Set("schedule_hours", job.Schedule.Time.Hours)
+	d.Set("schedule_cron", "")
+	if job.Schedule.Date.Type == "custom_cron" {
+		d.Set("schedule_cron", job.Schedule.Cron)
+	}
+	interval := job.Schedule.Time.Interval
+	if interval == 0 {
+		interval = 1
+	}
+	d.Set("schedule_interval", interval)
+
+	d.Set("job_completion_trigger_condition", flattenJobCompletionTrigger(job.JobCompletionTriggerCondition))
+}
+
+func flattenJobCompletionTrigger(trigger *dbtjob.JobCompletionTrigger) []interface{} {
+	if trigger == nil {
+		return make([]interface{}, 0)
+	}
+
+	statuses := make([]interface{}, 0, len(trigger.Condition.Statuses))
+	for _, code := range trigger.Condition.Statuses {
+		for status, statusCode := range jobRunStatuses {
+			if statusCode == code {
+				statuses = append(statuses, status)
+			}
+		}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"job_id":     trigger.Condition.JobId,
+		"project_id": trigger.Condition.ProjectId,
+		"statuses":   statuses,
+	}}
+}
+
+func readJobFromResourceData(data *schema.ResourceData, accountId int) *dbtjob.Job {
+	id, _ := strconv.Atoi(data.Id())
+
+	job := &dbtjob.Job{
+		Id:                     id,
+		AccountId:              accountId,
+		ProjectId:              data.Get("project_id").(int),
+		EnvironmentId:          data.Get("environment_id").(int),
+		Name:                   data.Get("name").(string),
+		Description:            data.Get("description").(string),
+		ExecuteSteps:           utils.InterfaceListToStringList(data.Get("execute_steps").([]interface{})),
+		DbtVersion:             utils.StringToPointer(data.Get("dbt_version").(string)),
+		GenerateDocs:           data.Get("generate_docs").(bool),
+		RunGenerateSources:     data.Get("run_generate_sources").(bool),
+		TimeoutSeconds:         data.Get("timeout_seconds").(int),
+		DeferringEnvironmentId: utils.IntToPointer(data.Get("deferring_environment_id").(int)),
+		Settings: dbtjob.JobSettings{
+			Threads:    data.Get("threads").(int),
+			TargetName: data.Get("target_name").(string),
+		},
+		Schedule: readJobScheduleFromResourceData(data),
+		State:    client.StateActive,
+	}
+
+	if triggers, ok := data.Get("triggers").([]interface{}); ok && len(triggers) > 0 && triggers[0] != nil {
+		t := triggers[0].(map[string]interface{})
+		job.Triggers = dbtjob.JobTriggers{
+			Schedule:           t["schedule"].(bool),
+			GithubWebhook:      t["github_webhook"].(bool),
+			GitProviderWebhook: t["git_provider_webhook"].(bool),
+			OnMerge:            t["on_merge"].(bool),
+		}
+	}
+
+	if conditions, ok := data.Get("job_completion_trigger_condition").([]interface{}); ok && len(conditions) > 0 && conditions[0] != nil {
+		condition := conditions[0].(map[string]interface{})
+
+		var statuses []int
+		for _, status := range condition["statuses"].(*schema.Set).List() {
+			statuses = append(statuses, jobRunStatuses[status.(string)])
+		}
+
+		job.JobCompletionTriggerCondition = &dbtjob.JobCompletionTrigger{
+			Condition: dbtjob.JobCompletionTriggerCondition{
+				JobId:     condition["job_id"].(int),
+				ProjectId: condition["project_id"].(int),
+				Statuses:  statuses,
+			},
+		}
+	}
+
+	return job
+}
+
+// readJobScheduleFromResourceData builds the schedule DBT expects, including the cron expression
+// DBT requires even when the schedule is given as days and hours.
+func readJobScheduleFromResourceData(data *schema.ResourceData) dbtjob.JobSchedule {
+	scheduleType := data.Get("schedule_type").(string)
+	days := utils.InterfaceListToIntList(data.Get("schedule_days").([]interface{}))
+	hours := utils.InterfaceListToIntList(data.Get("schedule_hours").([]interface{}))
+	interval := data.Get("schedule_interval").(int)
+
+	if scheduleType == "custom_cron" {
+		cron := data.Get("schedule_cron").(string)
+
+		return dbtjob.JobSchedule{
+			Cron: cron,
+			Date: dbtjob.JobScheduleDate{Type: scheduleType, Cron: &cron},
+			Time: dbtjob.JobScheduleTime{Type: "every_hour", Interval: 1},
+		}
+	}
+
+	schedule := dbtjob.JobSchedule{
+		Date: dbtjob.JobScheduleDate{Type: scheduleType},
+	}
+
+	cronDays := "*"
+	if scheduleType == "days_of_week" {
+		schedule.Date.Days = days
+		cronDays = joinInts(days)
+	}
+
+	var cronHours string
+	if len(hours) > 0 {
+		schedule.Time = dbtjob.JobScheduleTime{Type: "at_exact_hours", Hours: hours}
+		cronHours = joinInts(hours)
+	} else {
+		schedule.Time = dbtjob.JobScheduleTime{Type: "every_hour", Interval: interval}
+		cronHours = "*"
+		if interval > 1 {
+			cronHours = fmt.Sprintf("*/%d", interval)
+		}
+	}
+
+	schedule.Cron = fmt.Sprintf("0 %s * * %s", cronHours, cronDays)
+
+	return schedule
+}
+
+func joinInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,77 @@
+package dbt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResourceJobScheduleValidation(t *testing.T) {
+	tests := []struct {
+		name          string
+		schedule      map[string]interface{}
+		expectedError string
+	}{
+		{
+			name:     "every day",
+			schedule: map[string]interface{}{},
+		},
+		{
+			name:     "days of week",
+			schedule: map[string]interface{}{"schedule_type": "days_of_week", "schedule_days": []int{1, 3}},
+		},
+		{
+			name:          "days of week without days",
+			schedule:      map[string]interface{}{"schedule_type": "days_of_week"},
+			expectedError: "schedule_days must contain at least one day",
+		},
+		{
+			name:          "days of week with empty days",
+			schedule:      map[string]interface{}{"schedule_type": "days_of_week", "schedule_days": []int{}},
+			expectedError: "schedule_days must contain at least one day",
+		},
+		{
+			name:     "custom cron",
+			schedule: map[string]interface{}{"schedule_type": "custom_cron", "schedule_cron": "0 6 * * 1-5"},
+		},
+		{
+			name:          "custom cron without cron",
+			schedule:      map[string]interface{}{"schedule_type": "custom_cron"},
+			expectedError: "schedule_cron is required",
+		},
+		{
+			name:          "custom cron with empty cron",
+			schedule:      map[string]interface{}{"schedule_type": "custom_cron", "schedule_cron": " "},
+			expectedError: "schedule_cron is required",
+		},
+		{
+			name:          "custom cron with invalid cron",
+			schedule:      map[string]interface{}{"schedule_type": "custom_cron", "schedule_cron": "0 6 * *"},
+			expectedError: "must be a cron expression with 5 fields",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"project_id":     1,
+				"environment_id": 2,
+				"name":           "job",
+				"execute_steps":  []string{"dbt build"},
+				"triggers":       []interface{}{map[string]interface{}{"schedule": true}},
+			}
+			for key, value := range test.schedule {
+				config[key] = value
+			}
+
+			_, err := planResource(t, resourceJob(), "", nil, config, nil)
+
+			if test.expectedError == "" && err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if test.expectedError != "" && (err == nil || !strings.Contains(err.Error(), test.expectedError)) {
+				t.Fatalf("expected an error containing %q, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	"terraform-provider-dbt/dbt/client"
 	dbtlicensemap "terraform-provider-dbt/dbt/license_map"
 	utils "terraform-provider-dbt/dbt/utils"
 )
@@ -19,6 +21,12 @@ func resourceLicenseMap() *schema.Resource {
 		ReadContext:   resourceLicenseMapRead,
 		UpdateContext: resourceLicenseMapUpdate,
 		DeleteContext: resourceLicenseMapDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"license_type": {
 				Type:     schema.TypeString,
@@ -26,16 +34,17 @@ func resourceLicenseMap() *schema.Resource {
 				ValidateDiagFunc: func(i interface{}, p cty.Path) diag.Diagnostics {
 					value := i.(string)
 
-					if value == "developer" || value == "read_only" {
+					if dbtlicensemap.IsKnownLicenseType(value) {
 						return diag.Diagnostics{}
 					}
 
 					return diag.Diagnostics{diag.Diagnostic{
-						Severity: diag.Error,
-						Summary:  "License type is not valid",
-						Detail:   fmt.Sprintf("%q is not a valid group permission. Must be either 'developer' or 'read_only'", value),
+						Severity: diag.Warning,
+						Summary:  "Unknown license type",
+						Detail:   fmt.Sprintf("%q is not one of the known license types [%s]. It is checked against the seats of the DBT cloud account when it is applied, and DBT rejects it if it is not supported", value, strings.Join(dbtlicensemap.LicenseTypes, ", ")),
 					}}
 				},
+				Description: "The license type the sso groups are mapped to, e.g. developer, read_only, it or analyst",
 			},
 			"sso_license_mapping_groups": {
 				Type:     schema.TypeSet,
@@ -44,56 +53,214 @@ func resourceLicenseMap() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"authoritative": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Set this to true to let this resource own all sso groups of the license type. Groups added outside of terraform are then shown as drift and removed on apply. An authoritative license map must be the only dbt_license_map for its license type",
+			},
 		},
+		CustomizeDiff: resourceLicenseMapCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
-				licenseType, ssoGroups, err := resourceServiceLicenseMapParseId(d.Id())
+			StateContext: resourceLicenseMapImport,
+		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceLicenseMapV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceLicenseMapStateUpgradeV0,
+			},
+		},
+	}
+}
+
+// resourceLicenseMapImport accepts the license type as id, optionally followed by the groups the license map owns:
+// licenseType:ssoGroup1,ssoGroup2. Commas and backslashes in group names are escaped with a backslash. The id
+// licenseType:authoritative imports an authoritative license map owning all groups of the license type. A license
+// type without groups imports an additive license map that owns no groups until the next apply adds the configured
+// groups. Ids in the old licenseType:[ssoGroup1 ssoGroup2] format import an additive license map owning those groups.
+func resourceLicenseMapImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*client.Client)
+	licenseType := licenseTypeFromId(d.Id())
+
+	var groups []string
+	authoritative := false
+
+	if parts := strings.SplitN(d.Id(), ":", 2); len(parts) == 2 {
+		var err error
+		switch {
+		case parts[1] == "authoritative":
+			authoritative = true
+		case strings.HasPrefix(parts[1], "[") && strings.HasSuffix(parts[1], "]"):
+			groups = strings.Fields(strings.Trim(parts[1], "[]"))
+		default:
+			groups, err = parseLicenseMapImportGroups(parts[1])
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("unexpected format of ID (%s), expected license_type, license_type:authoritative or license_type:group1,group2: %w", d.Id(), err)
+		}
+	}
 
-				if err != nil {
-					return nil, err
-				}
+	var licenseMap *dbtlicensemap.LicenseMap
+	var diags diag.Diagnostics
+	if authoritative {
+		licenseMap, diags = dbtlicensemap.ReadAuthoritativeLicenseMap(ctx, c, c.AccountId, licenseType)
+	} else {
+		licenseMap, diags = dbtlicensemap.ReadLicenseMap(ctx, c, c.AccountId, licenseType, groups)
+	}
 
-				d.Set("license_type", licenseType)
-				d.Set("sso_license_mapping_groups", ssoGroups)
+	if diags.HasError() {
+		return nil, fmt.Errorf("could not read the %q license map: %s", licenseType, diags[0].Detail)
+	}
+
+	if licenseMap == nil {
+		return nil, fmt.Errorf("there is no license map for the license type %q", licenseType)
+	}
+
+	setResourceData(d, licenseMap)
+	d.Set("authoritative", authoritative)
+
+	return []*schema.ResourceData{d}, nil
+}
 
-				return []*schema.ResourceData{d}, nil
+// parseLicenseMapImportGroups splits a comma separated list of groups, where commas and backslashes that are part of
+// a group name are escaped with a backslash.
+func parseLicenseMapImportGroups(value string) ([]string, error) {
+	var groups []string
+	var group strings.Builder
+	escaped := false
+
+	for _, r := range value {
+		switch {
+		case escaped:
+			if r != ',' && r != '\\' {
+				return nil, fmt.Errorf("invalid escape sequence \\%c", r)
+			}
+			group.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ',':
+			groups = append(groups, group.String())
+			group.Reset()
+		default:
+			group.WriteRune(r)
+		}
+	}
+
+	if escaped {
+		return nil, fmt.Errorf("the groups end with an unfinished escape sequence")
+	}
+	groups = append(groups, group.String())
+
+	for _, group := range groups {
+		if group == "" {
+			return nil, fmt.Errorf("group names cannot be empty")
+		}
+	}
+
+	return groups, nil
+}
+
+func licenseTypeFromId(id string) string {
+	return strings.SplitN(id, ":", 2)[0]
+}
+
+// resourceLicenseMapV0 is the schema before the id was changed from licenseType:[ssoGroup1 ssoGroup2] to licenseType.
+func resourceLicenseMapV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"license_type": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"sso_license_mapping_groups": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"authoritative": {
+				Type:     schema.TypeBool,
+				Optional: true,
 			},
 		},
 	}
 }
 
-func resourceServiceLicenseMapParseId(id string) (string, []string, error) {
-	parts := strings.SplitN(id, ":", -1)
+func resourceLicenseMapStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
 
-	if len(parts) != 2 || parts[0] == "" || parts[1] == "" || !strings.HasPrefix(parts[1], "[") || !strings.HasSuffix(parts[1], "]") {
-		return "", nil, fmt.Errorf("unexpected format of ID (%s), expected licenseType:[ssoGroup1 ssoGroup2]", id)
+	if id, ok := rawState["id"].(string); ok {
+		rawState["id"] = licenseTypeFromId(id)
 	}
 
-	licenseType := parts[0]
-	ssoGroups := strings.Split(parts[1][1:len(parts[1])-1], " ")
+	return rawState, nil
+}
 
-	return licenseType, ssoGroups, nil
+func resourceLicenseMapCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("license_type") {
+		return nil
+	}
+
+	c := m.(*client.Client)
+	licenseType := d.Get("license_type").(string)
+
+	return dbtlicensemap.ClaimLicenseType(c.AccountId, licenseType, d.GetRawConfig().GoString(), d.Id() != "", d.Get("authoritative").(bool))
 }
 
 func resourceLicenseMapCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	licenseType, mappingGroups, serviceToken, accountId := getInputData(d, m)
+	licenseType, mappingGroups, c := getInputData(d, m)
+	warnings := validateLicenseTypeForAccount(ctx, c, licenseType)
 
-	licenseMap, diags := dbtlicensemap.CreateOrUpdateLicenseMap(
-		accountId, licenseType, mappingGroups, nil, serviceToken)
+	var licenseMap *dbtlicensemap.LicenseMap
+	var diags diag.Diagnostics
+	if d.Get("authoritative").(bool) {
+		licenseMap, diags = dbtlicensemap.SetLicenseMapGroups(ctx, c, c.AccountId, licenseType, mappingGroups)
+	} else {
+		licenseMap, diags = dbtlicensemap.CreateOrUpdateLicenseMap(
+			ctx, c, c.AccountId, licenseType, mappingGroups, nil)
+	}
 
 	if diags != nil {
-		return diags
+		return append(warnings, diags...)
 	}
 
 	setResourceData(d, licenseMap)
 
-	return diags
+	return warnings
+}
+
+// validateLicenseTypeForAccount returns warnings for license types that are not known by the provider and not listed
+// by the account. This runs on apply, since diagnostics of CustomizeDiff cannot be warnings.
+func validateLicenseTypeForAccount(ctx context.Context, c *client.Client, licenseType string) diag.Diagnostics {
+	if dbtlicensemap.IsKnownLicenseType(licenseType) {
+		return nil
+	}
+
+	return dbtlicensemap.ValidateLicenseTypeForAccount(ctx, c, c.AccountId, licenseType)
 }
 
 func resourceLicenseMapRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	licenseType, mappingGroups, serviceToken, accountId := getInputData(d, m)
+	licenseType, mappingGroups, c := getInputData(d, m)
 
-	licenseMap, _ := dbtlicensemap.ReadLicenseMap(accountId, licenseType, mappingGroups, serviceToken)
+	var licenseMap *dbtlicensemap.LicenseMap
+	var diags diag.Diagnostics
+	if d.Get("authoritative").(bool) {
+		licenseMap, diags = dbtlicensemap.ReadAuthoritativeLicenseMap(ctx, c, c.AccountId, licenseType)
+	} else {
+		licenseMap, diags = dbtlicensemap.ReadLicenseMap(ctx, c, c.AccountId, licenseType, mappingGroups)
+	}
+
+	if diags != nil {
+		return diags
+	}
 
 	setResourceData(d, licenseMap)
 
@@ -101,27 +268,43 @@ func resourceLicenseMapRead(ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceLicenseMapUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	licenseType, _, serviceToken, accountId := getInputData(d, m)
+	licenseType, _, c := getInputData(d, m)
+
+	var warnings diag.Diagnostics
+	if d.HasChange("license_type") {
+		warnings = validateLicenseTypeForAccount(ctx, c, licenseType)
+	}
 
 	old, new := d.GetChange("sso_license_mapping_groups")
 
-	licenseMap, diags := dbtlicensemap.CreateOrUpdateLicenseMap(
-		accountId, licenseType, utils.InterfaceToStringList(new), utils.InterfaceToStringList(old), serviceToken)
+	var licenseMap *dbtlicensemap.LicenseMap
+	var diags diag.Diagnostics
+	if d.Get("authoritative").(bool) {
+		licenseMap, diags = dbtlicensemap.SetLicenseMapGroups(ctx, c, c.AccountId, licenseType, utils.InterfaceToStringList(new))
+	} else {
+		licenseMap, diags = dbtlicensemap.CreateOrUpdateLicenseMap(
+			ctx, c, c.AccountId, licenseType, utils.InterfaceToStringList(new), utils.InterfaceToStringList(old))
+	}
 
 	if diags != nil {
-		return diags
+		return append(warnings, diags...)
 	}
 
 	setResourceData(d, licenseMap)
 
-	return diags
+	return warnings
 }
 
 func resourceLicenseMapDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	licenseType, mappingGroups, serviceToken, accountId := getInputData(d, m)
+	licenseType, mappingGroups, c := getInputData(d, m)
 
-	_, diags := dbtlicensemap.CreateOrUpdateLicenseMap(
-		accountId, licenseType, nil, mappingGroups, serviceToken)
+	var diags diag.Diagnostics
+	if d.Get("authoritative").(bool) {
+		_, diags = dbtlicensemap.SetLicenseMapGroups(ctx, c, c.AccountId, licenseType, nil)
+	} else {
+		_, diags = dbtlicensemap.CreateOrUpdateLicenseMap(
+			ctx, c, c.AccountId, licenseType, nil, mappingGroups)
+	}
 
 	d.SetId("")
 
@@ -130,7 +313,7 @@ func resourceLicenseMapDelete(ctx context.Context, d *schema.ResourceData, m int
 
 func setResourceData(data *schema.ResourceData, licenseMap *dbtlicensemap.LicenseMap) {
 	if licenseMap != nil {
-		data.SetId(fmt.Sprintf("%s:%s", licenseMap.LicenseType, licenseMap.SsoLicenseMappingGroups))
+		data.SetId(licenseMap.LicenseType)
 		data.Set("license_type", licenseMap.LicenseType)
 		data.Set("sso_license_mapping_groups", licenseMap.SsoLicenseMappingGroups)
 	} else {
@@ -138,11 +321,11 @@ func setResourceData(data *schema.ResourceData, licenseMap *dbtlicensemap.Licens
 	}
 }
 
-func getInputData(data *schema.ResourceData, m interface{}) (string, []string, string, int) {
-	providerInput := m.(*DbtProviderInput)
+func getInputData(data *schema.ResourceData, m interface{}) (string, []string, *client.Client) {
+	c := m.(*client.Client)
 
 	licenseType := data.Get("license_type").(string)
 	ssoLicenseMappingGroups := utils.InterfaceToStringList(data.Get("sso_license_mapping_groups"))
 
-	return licenseType, ssoLicenseMappingGroups, providerInput.ServiceToken, providerInput.AccountId
+	return licenseType, ssoLicenseMappingGroups, c
 }
@@ -0,0 +1,237 @@
+package dbt
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"terraform-provider-dbt/dbt/client"
+	dbtlicensemap "terraform-provider-dbt/dbt/license_map"
+	utils "terraform-provider-dbt/dbt/utils"
+)
+
+func TestResourceLicenseMapReplaceAuthoritative(t *testing.T) {
+	t.Cleanup(dbtlicensemap.ResetClaims)
+	c := &client.Client{AccountId: 1}
+	r := resourceLicenseMap()
+
+	config := map[string]interface{}{
+		"license_type":               "developer",
+		"sso_license_mapping_groups": []string{"analysts"},
+		"authoritative":              true,
+	}
+	state := map[string]string{
+		"id":                           "developer",
+		"license_type":                 "developer",
+		"sso_license_mapping_groups.#": "1",
+		"sso_license_mapping_groups.0": "analysts",
+		"authoritative":                "false",
+	}
+
+	diff, err := planResource(t, r, "developer", state, config, c)
+	if err != nil {
+		t.Fatalf("expected the plan to succeed, got %s", err)
+	}
+
+	if !diff.RequiresNew() {
+		t.Fatalf("expected changing authoritative to replace the license map")
+	}
+
+	// Terraform plans a replaced resource again without its prior state.
+	_, err = planResource(t, r, "", nil, config, c)
+	if err != nil {
+		t.Fatalf("expected the second plan of the replaced license map to succeed, got %s", err)
+	}
+
+	other := map[string]interface{}{
+		"license_type":               "developer",
+		"sso_license_mapping_groups": []string{"engineers"},
+	}
+
+	_, err = planResource(t, r, "", nil, other, c)
+	if err == nil || !strings.Contains(err.Error(), "managed by more than one dbt_license_map") {
+		t.Fatalf("expected another license map for the license type to fail, got %v", err)
+	}
+}
+
+func TestResourceLicenseMapDuplicateAuthoritative(t *testing.T) {
+	t.Cleanup(dbtlicensemap.ResetClaims)
+
+	c := &client.Client{AccountId: 1}
+	config := map[string]interface{}{
+		"license_type":               "developer",
+		"sso_license_mapping_groups": []string{"analysts"},
+		"authoritative":              true,
+	}
+
+	// Instances of a resource with count have the same arguments.
+	_, err := planResource(t, resourceLicenseMap(), "", nil, config, c)
+	if err != nil {
+		t.Fatalf("expected the first instance to plan, got %s", err)
+	}
+
+	_, err = planResource(t, resourceLicenseMap(), "", nil, config, c)
+	if err == nil || !strings.Contains(err.Error(), "managed by more than one dbt_license_map") {
+		t.Fatalf("expected a second authoritative instance to fail, got %v", err)
+	}
+}
+
+func TestResourceLicenseMapImport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": [{"id": 5, "account_id": 1, "license_type": "developer", "sso_license_mapping_groups": ["ui", "analysts", "finance, europe"], "state": 1}]}`))
+	}))
+	defer server.Close()
+
+	c := client.NewClient(server.URL, "token", 1)
+
+	tests := []struct {
+		id                    string
+		expectedGroups        []string
+		expectedAuthoritative bool
+	}{
+		{id: "developer", expectedGroups: []string{}},
+		{id: "developer:authoritative", expectedGroups: []string{"ui", "analysts", "finance, europe"}, expectedAuthoritative: true},
+		{id: "developer:analysts", expectedGroups: []string{"analysts"}},
+		{id: `developer:analysts,finance\, europe`, expectedGroups: []string{"analysts", "finance, europe"}},
+		{id: "developer:analysts,engineers", expectedGroups: []string{"analysts"}},
+		{id: "developer:[analysts]", expectedGroups: []string{"analysts"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.id, func(t *testing.T) {
+			t.Cleanup(dbtlicensemap.ResetClaims)
+			r := resourceLicenseMap()
+			d := r.TestResourceData()
+			d.SetId(test.id)
+
+			imported, err := r.Importer.StateContext(context.Background(), d, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			d = imported[0]
+			if d.Id() != "developer" {
+				t.Errorf("expected the id developer, got %s", d.Id())
+			}
+
+			groups := utils.InterfaceToStringList(d.Get("sso_license_mapping_groups"))
+			sort.Strings(groups)
+			sort.Strings(test.expectedGroups)
+			if !reflect.DeepEqual(groups, test.expectedGroups) {
+				t.Errorf("expected the groups %v, got %v", test.expectedGroups, groups)
+			}
+
+			if d.Get("authoritative").(bool) != test.expectedAuthoritative {
+				t.Errorf("expected authoritative to be %v", test.expectedAuthoritative)
+			}
+
+			// Planning the configuration the groups were imported for gives no changes.
+			groupList := []interface{}{}
+			for _, group := range test.expectedGroups {
+				groupList = append(groupList, group)
+			}
+			config := map[string]interface{}{"license_type": "developer", "sso_license_mapping_groups": groupList, "authoritative": test.expectedAuthoritative}
+
+			diff, err := planResource(t, r, d.Id(), d.State().Attributes, config, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(test.expectedGroups) > 0 && diff != nil && !diff.Empty() {
+				t.Errorf("expected no changes after the import, got %v", diff)
+			}
+		})
+	}
+}
+
+func TestParseLicenseMapImportGroups(t *testing.T) {
+	tests := []struct {
+		value          string
+		expectedGroups []string
+		expectError    bool
+	}{
+		{value: "analysts", expectedGroups: []string{"analysts"}},
+		{value: "analysts,data engineers", expectedGroups: []string{"analysts", "data engineers"}},
+		{value: `finance\, europe,back\\slash`, expectedGroups: []string{"finance, europe", `back\slash`}},
+		{value: "analysts,", expectError: true},
+		{value: `analysts\`, expectError: true},
+		{value: `analysts\n`, expectError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			groups, err := parseLicenseMapImportGroups(test.value)
+
+			if (err != nil) != test.expectError {
+				t.Fatalf("expected error to be %v, got %v", test.expectError, err)
+			}
+
+			if !test.expectError && !reflect.DeepEqual(groups, test.expectedGroups) {
+				t.Errorf("expected the groups %v, got %v", test.expectedGroups, groups)
+			}
+		})
+	}
+}
+
+func TestResourceLicenseMapUnlistedLicenseType(t *testing.T) {
+	tests := []struct {
+		name             string
+		accountStatus    int
+		account          string
+		expectedWarnings int
+	}{
+		{name: "account lists the seats", accountStatus: http.StatusOK, account: `{"data": {"id": 1, "security_seats": 2}}`},
+		{name: "account does not list the seats", accountStatus: http.StatusOK, account: `{"data": {"id": 1, "developer_seats": 10}}`, expectedWarnings: 1},
+		{name: "account cannot be read", accountStatus: http.StatusForbidden, account: `{"status": {"code": 403}}`, expectedWarnings: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Cleanup(dbtlicensemap.ResetClaims)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method + " " + r.URL.Path {
+				case "GET /api/v2/accounts/1/":
+					w.WriteHeader(test.accountStatus)
+					w.Write([]byte(test.account))
+				case "GET /api/v3/accounts/1/license-maps/":
+					w.Write([]byte(`{"data": []}`))
+				case "POST /api/v3/accounts/1/license-maps/":
+					w.Write([]byte(`{"data": {"id": 5, "account_id": 1, "license_type": "security", "sso_license_mapping_groups": ["auditors"], "state": 1}}`))
+				default:
+					http.Error(w, "unexpected request", http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+			c := client.NewClient(server.URL, "token", 1)
+
+			config := map[string]interface{}{
+				"license_type":               "security",
+				"sso_license_mapping_groups": []interface{}{"auditors"},
+			}
+
+			// The account is only checked on apply, so planning works without access to it.
+			_, err := planResource(t, resourceLicenseMap(), "", nil, config, &client.Client{AccountId: 1})
+			if err != nil {
+				t.Fatalf("expected an unlisted license type to plan, got %s", err)
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceLicenseMap().Schema, config)
+			diags := resourceLicenseMapCreate(context.Background(), d, c)
+
+			if diags.HasError() || len(diags) != test.expectedWarnings {
+				t.Fatalf("expected %d warnings, got %v", test.expectedWarnings, diags)
+			}
+
+			if d.Id() != "security" {
+				t.Errorf("expected the license map to be created, got id %q", d.Id())
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package dbt
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	dbtcredential "terraform-provider-dbt/dbt/credential"
+)
+
+// resourcePostgresCredential is also used for Redshift, which has the same credentials.
+func resourcePostgresCredential() *schema.Resource {
+	return resourceCredential(credentialAdapter{
+		toCredential: func(d *schema.ResourceData, credential *dbtcredential.Credential) {
+			credential.Type = d.Get("type").(string)
+			credential.Username = d.Get("username").(string)
+			credential.DefaultSchema = d.Get("default_schema").(string)
+			credential.Password = d.Get("password").(string)
+		},
+		setState: func(d *schema.ResourceData, credential *dbtcredential.Credential) {
+			d.Set("type", credential.Type)
+			d.Set("username", credential.Username)
+			d.Set("default_schema", credential.DefaultSchema)
+		},
+	}, map[string]*schema.Schema{
+		"type": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			Default:          "postgres",
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"postgres", "redshift"}, false)),
+			Description:      "Either postgres or redshift. Defaults to postgres",
+		},
+		"username": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The user dbt runs as",
+		},
+		"default_schema": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The schema dbt builds models in",
+		},
+		"password": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Sensitive:   true,
+			Description: "The password of the user. DBT never returns it, so it is kept in the terraform state as it was last written",
+		},
+	})
+}
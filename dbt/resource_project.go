@@ -0,0 +1,145 @@
+package dbt
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"terraform-provider-dbt/dbt/client"
+	dbtproject "terraform-provider-dbt/dbt/project"
+	utils "terraform-provider-dbt/dbt/utils"
+)
+
+func resourceProject() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceProjectCreate,
+		ReadContext:   resourceProjectRead,
+		UpdateContext: resourceProjectUpdate,
+		DeleteContext: resourceProjectDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the project",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the project",
+			},
+			"dbt_project_subdirectory": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to the dbt project inside the repository, if it is not in the root",
+			},
+			"connection_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Id of the warehouse connection used by the project. Leave it out and use dbt_project_connection when the connection is created by terraform, since dbt_connection needs the id of the project. A connection_id that is left out is not changed",
+			},
+			"repository_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Id of the git repository used by the project. Leave it out and use dbt_project_repository when the repository is created by terraform, since dbt_repository needs the id of the project. A repository_id that is left out is not changed",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+	projectInput := readProjectFromResourceData(d, c.AccountId)
+
+	project, diags := dbtproject.CreateProject(ctx, c, projectInput)
+	if diags != nil {
+		return diags
+	}
+
+	setStateFromProject(d, project)
+
+	return nil
+}
+
+func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+	projectId, _ := strconv.Atoi(d.Id())
+
+	project, diags := dbtproject.ReadProject(ctx, c, c.AccountId, projectId)
+	if diags != nil {
+		return diags
+	}
+
+	if project != nil {
+		setStateFromProject(d, project)
+	} else {
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+	projectInput := readProjectFromResourceData(d, c.AccountId)
+
+	project, diags := dbtproject.UpdateProject(ctx, c, projectInput)
+	if diags != nil {
+		return diags
+	}
+
+	setStateFromProject(d, project)
+
+	return nil
+}
+
+func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+	projectId, _ := strconv.Atoi(d.Id())
+
+	diags := dbtproject.DeleteProject(ctx, c, c.AccountId, projectId)
+
+	if diags == nil {
+		d.SetId("")
+	}
+
+	return diags
+}
+
+func setStateFromProject(d *schema.ResourceData, project *dbtproject.Project) {
+	d.SetId(strconv.Itoa(project.Id))
+	d.Set("name", project.Name)
+	d.Set("description", project.Description)
+	d.Set("dbt_project_subdirectory", utils.PointerToString(project.DbtProjectSubdirectory))
+	d.Set("connection_id", utils.PointerToInt(project.ConnectionId))
+	d.Set("repository_id", utils.PointerToInt(project.RepositoryId))
+}
+
+func readProjectFromResourceData(data *schema.ResourceData, accountId int) *dbtproject.Project {
+	id, _ := strconv.Atoi(data.Id())
+
+	project := &dbtproject.Project{
+		Id:                     id,
+		AccountId:              accountId,
+		Name:                   data.Get("name").(string),
+		Description:            data.Get("description").(string),
+		DbtProjectSubdirectory: utils.StringToPointer(data.Get("dbt_project_subdirectory").(string)),
+		ConnectionId:           utils.IntToPointer(data.Get("connection_id").(int)),
+		RepositoryId:           utils.IntToPointer(data.Get("repository_id").(int)),
+	}
+
+	return project
+}
@@ -0,0 +1,22 @@
+package dbt
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	dbtproject "terraform-provider-dbt/dbt/project"
+)
+
+var projectConnectionLink = projectLink{
+	attribute:   "connection_id",
+	description: "The connection_id of the dbt_connection to use for the project",
+	get: func(project *dbtproject.Project) *int {
+		return project.ConnectionId
+	},
+	set: func(project *dbtproject.Project, id *int) {
+		project.ConnectionId = id
+	},
+}
+
+func resourceProjectConnection() *schema.Resource {
+	return resourceProjectLink(projectConnectionLink)
+}
@@ -0,0 +1,137 @@
+package dbt
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"terraform-provider-dbt/dbt/client"
+	dbtproject "terraform-provider-dbt/dbt/project"
+	utils "terraform-provider-dbt/dbt/utils"
+)
+
+// projectLink attaches an object that belongs to a project, like its connection, to the project. The object needs
+// the id of the project to be created, so the project cannot reference the object without a dependency cycle.
+type projectLink struct {
+	attribute   string
+	description string
+	get         func(project *dbtproject.Project) *int
+	set         func(project *dbtproject.Project, id *int)
+}
+
+func resourceProjectLink(link projectLink) *schema.Resource {
+	return &schema.Resource{
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return resourceProjectLinkCreate(ctx, d, m, link)
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return resourceProjectLinkRead(ctx, d, m, link)
+		},
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return resourceProjectLinkDelete(ctx, d, m, link)
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Id of the project",
+			},
+			link.attribute: {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: link.description,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceProjectLinkCreate(ctx context.Context, d *schema.ResourceData, m interface{}, link projectLink) diag.Diagnostics {
+	c := m.(*client.Client)
+	projectId := d.Get("project_id").(int)
+	id := d.Get(link.attribute).(int)
+
+	project, diags := dbtproject.ReadProject(ctx, c, c.AccountId, projectId)
+	if diags != nil {
+		return diags
+	}
+
+	if project == nil {
+		return diag.Errorf("The project %d does not exist", projectId)
+	}
+
+	link.set(project, &id)
+
+	_, diags = dbtproject.UpdateProject(ctx, c, project)
+	if diags != nil {
+		return diags
+	}
+
+	d.SetId(utils.FormatProjectScopedId(projectId, id))
+
+	return nil
+}
+
+func resourceProjectLinkRead(ctx context.Context, d *schema.ResourceData, m interface{}, link projectLink) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	projectId, _, err := utils.ParseProjectScopedId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	project, diags := dbtproject.ReadProject(ctx, c, c.AccountId, projectId)
+	if diags != nil {
+		return diags
+	}
+
+	// A project that was unlinked outside of terraform is linked again on the next apply.
+	if project == nil || link.get(project) == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("project_id", projectId)
+	d.Set(link.attribute, *link.get(project))
+
+	return nil
+}
+
+func resourceProjectLinkDelete(ctx context.Context, d *schema.ResourceData, m interface{}, link projectLink) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	projectId, id, err := utils.ParseProjectScopedId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	project, diags := dbtproject.ReadProject(ctx, c, c.AccountId, projectId)
+	if diags != nil {
+		return diags
+	}
+
+	// The project may already be linked to another object, e.g. when the link is replaced.
+	if project != nil && link.get(project) != nil && *link.get(project) == id {
+		link.set(project, nil)
+
+		_, diags = dbtproject.UpdateProject(ctx, c, project)
+		if diags != nil {
+			return diags
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package dbt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"terraform-provider-dbt/dbt/client"
+	dbtproject "terraform-provider-dbt/dbt/project"
+	utils "terraform-provider-dbt/dbt/utils"
+)
+
+// fakeProject serves project 5 of account 1.
+type fakeProject struct {
+	project dbtproject.Project
+	updates int
+}
+
+func (f *fakeProject) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/api/v3/accounts/1/projects/5/" {
+		http.Error(w, fmt.Sprintf("unexpected request %s %s", r.Method, r.URL.Path), http.StatusNotFound)
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		json.NewDecoder(r.Body).Decode(&f.project)
+		f.updates++
+	}
+
+	json.NewEncoder(w).Encode(client.Response[dbtproject.Project]{Data: f.project})
+}
+
+func TestResourceProjectConnection(t *testing.T) {
+	description := "The analytics project"
+	fake := &fakeProject{project: dbtproject.Project{Id: 5, AccountId: 1, Name: "analytics", Description: description, RepositoryId: utils.IntToPointer(8), State: client.StateActive}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	c := client.NewClient(server.URL, "token", 1)
+
+	r := resourceProjectConnection()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"project_id": 5, "connection_id": 7})
+
+	if diags := r.CreateContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("expected no error, got %v", diags)
+	}
+
+	if d.Id() != "5:7" || utils.PointerToInt(fake.project.ConnectionId) != 7 {
+		t.Fatalf("expected the project to be linked to connection 7, got id %s and connection %v", d.Id(), fake.project.ConnectionId)
+	}
+
+	if fake.project.Name != "analytics" || fake.project.Description != description || utils.PointerToInt(fake.project.RepositoryId) != 8 {
+		t.Errorf("expected the other settings of the project to be kept, got %+v", fake.project)
+	}
+
+	// Linked to another connection outside of terraform, the link shows up as a change and the connection is kept on delete.
+	fake.project.ConnectionId = utils.IntToPointer(9)
+
+	if diags := r.ReadContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("expected no error, got %v", diags)
+	}
+
+	if d.Id() != "5:7" || d.Get("connection_id").(int) != 9 {
+		t.Errorf("expected the link to be read as connection 9, got id %s and connection %d", d.Id(), d.Get("connection_id"))
+	}
+
+	updates := fake.updates
+	if diags := r.DeleteContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("expected no error, got %v", diags)
+	}
+
+	if fake.updates != updates || utils.PointerToInt(fake.project.ConnectionId) != 9 {
+		t.Errorf("expected the project to keep connection 9, got %v", fake.project.ConnectionId)
+	}
+
+	// Unlinked outside of terraform, the link is removed from the state.
+	d.SetId("5:7")
+	fake.project.ConnectionId = nil
+
+	if diags := r.ReadContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("expected no error, got %v", diags)
+	}
+
+	if d.Id() != "" {
+		t.Errorf("expected the link to be removed from the state, got id %s", d.Id())
+	}
+
+	// Deleting the link removes the connection from the project.
+	d.SetId("5:7")
+	fake.project.ConnectionId = utils.IntToPointer(7)
+
+	if diags := r.DeleteContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("expected no error, got %v", diags)
+	}
+
+	if fake.project.ConnectionId != nil {
+		t.Errorf("expected the connection to be removed from the project, got %v", *fake.project.ConnectionId)
+	}
+}
+
+func TestResourceProjectRepository(t *testing.T) {
+	fake := &fakeProject{project: dbtproject.Project{Id: 5, AccountId: 1, Name: "analytics", ConnectionId: utils.IntToPointer(7), State: client.StateActive}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	c := client.NewClient(server.URL, "token", 1)
+
+	r := resourceProjectRepository()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"project_id": 5, "repository_id": 8})
+
+	if diags := r.CreateContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("expected no error, got %v", diags)
+	}
+
+	if d.Id() != "5:8" || utils.PointerToInt(fake.project.RepositoryId) != 8 || utils.PointerToInt(fake.project.ConnectionId) != 7 {
+		t.Fatalf("expected the project to be linked to repository 8 and keep connection 7, got %+v", fake.project)
+	}
+
+	if diags := r.DeleteContext(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("expected no error, got %v", diags)
+	}
+
+	if fake.project.RepositoryId != nil || utils.PointerToInt(fake.project.ConnectionId) != 7 {
+		t.Errorf("expected only the repository to be removed from the project, got %+v", fake.project)
+	}
+}
+
+func TestResourceProjectKeepsLinks(t *testing.T) {
+	attributes := map[string]string{
+		"id":            "5",
+		"name":          "analytics",
+		"connection_id": "7",
+		"repository_id": "8",
+	}
+	config := map[string]interface{}{"name": "analytics"}
+
+	diff, err := planResource(t, resourceProject(), "5", attributes, config, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("expected no changes, got %v", diff)
+	}
+}
@@ -0,0 +1,22 @@
+package dbt
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	dbtproject "terraform-provider-dbt/dbt/project"
+)
+
+var projectRepositoryLink = projectLink{
+	attribute:   "repository_id",
+	description: "The repository_id of the dbt_repository to use for the project",
+	get: func(project *dbtproject.Project) *int {
+		return project.RepositoryId
+	},
+	set: func(project *dbtproject.Project, id *int) {
+		project.RepositoryId = id
+	},
+}
+
+func resourceProjectRepository() *schema.Resource {
+	return resourceProjectLink(projectRepositoryLink)
+}
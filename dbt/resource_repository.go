@@ -0,0 +1,251 @@
+package dbt
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"terraform-provider-dbt/dbt/client"
+	dbtrepository "terraform-provider-dbt/dbt/repository"
+	utils "terraform-provider-dbt/dbt/utils"
+)
+
+// repositoryStrategyAttributes are the attributes only used by each git_clone_strategy.
+var repositoryStrategyAttributes = map[string][]string{
+	"deploy_key":                 {},
+	"github_app":                 {"github_installation_id"},
+	"deploy_token":               {"gitlab_project_id"},
+	"azure_active_directory_app": {"azure_active_directory_project_id", "azure_active_directory_repository_id", "azure_bypass_webhook_registration_failure"},
+}
+
+// repositoryRequiredAttributes are the attributes each git_clone_strategy cannot be created without.
+var repositoryRequiredAttributes = map[string][]string{
+	"github_app":                 {"github_installation_id"},
+	"deploy_token":               {"gitlab_project_id"},
+	"azure_active_directory_app": {"azure_active_directory_project_id", "azure_active_directory_repository_id"},
+}
+
+func resourceRepository() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRepositoryCreate,
+		ReadContext:   resourceRepositoryRead,
+		UpdateContext: resourceRepositoryUpdate,
+		DeleteContext: resourceRepositoryDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Id of the project the repository belongs to",
+			},
+			"repository_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The numeric id of the repository in DBT cloud, used as repository_id of dbt_project_repository",
+			},
+			"remote_url": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The git url of the repository, e.g. git@github.com:org/repo.git",
+			},
+			"git_clone_strategy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "deploy_key",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"deploy_key", "github_app", "deploy_token", "azure_active_directory_app"}, false)),
+				Description:      "How DBT clones the repository, one of deploy_key, github_app, deploy_token (GitLab) or azure_active_directory_app (Azure DevOps). Defaults to deploy_key",
+			},
+			"github_installation_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Id of the installation of the DBT GitHub App. Required for github_app",
+			},
+			"gitlab_project_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Id of the GitLab project. Required for deploy_token",
+			},
+			"azure_active_directory_project_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Id of the Azure DevOps project. Required for azure_active_directory_app",
+			},
+			"azure_active_directory_repository_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Id of the Azure DevOps repository. Required for azure_active_directory_app",
+			},
+			"azure_bypass_webhook_registration_failure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Create the repository even if DBT cannot register its webhooks in Azure DevOps. Only used by azure_active_directory_app",
+			},
+			"pull_request_url_template": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The url DBT links to for opening pull requests. DBT sets it for github_app and azure_active_directory_app if not set, e.g. https://github.com/org/repo/compare/{{destination}}...{{source}}",
+			},
+			"deploy_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The public key generated by DBT for the deploy_key strategy, to be added as a deploy key of the repository",
+			},
+		},
+		CustomizeDiff: resourceRepositoryCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+// resourceRepositoryCustomizeDiff checks that the attributes of the git_clone_strategy are set, and that the attributes of
+// the other strategies are not.
+func resourceRepositoryCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || !d.NewValueKnown("git_clone_strategy") {
+		return nil
+	}
+
+	strategy := d.Get("git_clone_strategy").(string)
+
+	for _, attribute := range repositoryRequiredAttributes[strategy] {
+		if config.GetAttr(attribute).IsNull() {
+			return fmt.Errorf("%s is required when git_clone_strategy is %s", attribute, strategy)
+		}
+	}
+
+	for otherStrategy, attributes := range repositoryStrategyAttributes {
+		if otherStrategy == strategy {
+			continue
+		}
+
+		for _, attribute := range attributes {
+			if !config.GetAttr(attribute).IsNull() {
+				return fmt.Errorf("%s can only be used when git_clone_strategy is %s", attribute, otherStrategy)
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceRepositoryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	repository, diags := dbtrepository.CreateRepository(ctx, c, readRepositoryFromResourceData(d, c.AccountId))
+	if diags != nil {
+		return diags
+	}
+
+	setStateFromRepository(d, repository)
+
+	return nil
+}
+
+func resourceRepositoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	projectId, repositoryId, err := utils.ParseProjectScopedId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	repository, diags := dbtrepository.ReadRepository(ctx, c, c.AccountId, projectId, repositoryId)
+	if diags != nil {
+		return diags
+	}
+
+	if repository != nil {
+		setStateFromRepository(d, repository)
+	} else {
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceRepositoryUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	repository, diags := dbtrepository.UpdateRepository(ctx, c, readRepositoryFromResourceData(d, c.AccountId))
+	if diags != nil {
+		return diags
+	}
+
+	setStateFromRepository(d, repository)
+
+	return nil
+}
+
+func resourceRepositoryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	projectId, repositoryId, err := utils.ParseProjectScopedId(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	diags := dbtrepository.DeleteRepository(ctx, c, c.AccountId, projectId, repositoryId)
+
+	if diags == nil {
+		d.SetId("")
+	}
+
+	return diags
+}
+
+func setStateFromRepository(d *schema.ResourceData, repository *dbtrepository.Repository) {
+	d.SetId(utils.FormatProjectScopedId(repository.ProjectId, repository.Id))
+	d.Set("project_id", repository.ProjectId)
+	d.Set("repository_id", repository.Id)
+	d.Set("remote_url", repository.RemoteUrl)
+	d.Set("git_clone_strategy", repository.GitCloneStrategy)
+	d.Set("github_installation_id", utils.PointerToInt(repository.GithubInstallationId))
+	d.Set("gitlab_project_id", utils.PointerToInt(repository.GitlabProjectId))
+	d.Set("azure_active_directory_project_id", utils.PointerToString(repository.AzureActiveDirectoryProjectId))
+	d.Set("azure_active_directory_repository_id", utils.PointerToString(repository.AzureActiveDirectoryRepositoryId))
+	d.Set("azure_bypass_webhook_registration_failure", repository.AzureBypassWebhookRegistrationFailure)
+	d.Set("pull_request_url_template", utils.PointerToString(repository.PullRequestUrlTemplate))
+
+	if repository.DeployKey != nil {
+		d.Set("deploy_key", repository.DeployKey.PublicKey)
+	} else {
+		d.Set("deploy_key", "")
+	}
+}
+
+func readRepositoryFromResourceData(data *schema.ResourceData, accountId int) *dbtrepository.Repository {
+	return &dbtrepository.Repository{
+		Id:                                    data.Get("repository_id").(int),
+		AccountId:                             accountId,
+		ProjectId:                             data.Get("project_id").(int),
+		RemoteUrl:                             data.Get("remote_url").(string),
+		GitCloneStrategy:                      data.Get("git_clone_strategy").(string),
+		GithubInstallationId:                  utils.IntToPointer(data.Get("github_installation_id").(int)),
+		GitlabProjectId:                       utils.IntToPointer(data.Get("gitlab_project_id").(int)),
+		AzureActiveDirectoryProjectId:         utils.StringToPointer(data.Get("azure_active_directory_project_id").(string)),
+		AzureActiveDirectoryRepositoryId:      utils.StringToPointer(data.Get("azure_active_directory_repository_id").(string)),
+		AzureBypassWebhookRegistrationFailure: data.Get("azure_bypass_webhook_registration_failure").(bool),
+		PullRequestUrlTemplate:                utils.StringToPointer(data.Get("pull_request_url_template").(string)),
+		State:                                 client.StateActive,
+	}
+}
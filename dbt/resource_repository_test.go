@@ -0,0 +1,66 @@
+package dbt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResourceRepositoryStrategyValidation(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        map[string]interface{}
+		unknown       []string
+		expectedError string
+	}{
+		{
+			name:   "deploy key",
+			config: map[string]interface{}{},
+		},
+		{
+			name:   "github app",
+			config: map[string]interface{}{"git_clone_strategy": "github_app", "github_installation_id": 123},
+		},
+		{
+			name:          "github app without installation id",
+			config:        map[string]interface{}{"git_clone_strategy": "github_app"},
+			expectedError: "github_installation_id is required when git_clone_strategy is github_app",
+		},
+		{
+			name:          "attribute of another strategy",
+			config:        map[string]interface{}{"git_clone_strategy": "github_app", "github_installation_id": 123, "gitlab_project_id": 456},
+			expectedError: "gitlab_project_id can only be used when git_clone_strategy is deploy_token",
+		},
+		{
+			name:    "unknown strategy",
+			config:  map[string]interface{}{"git_clone_strategy": "github_app", "github_installation_id": 123},
+			unknown: []string{"git_clone_strategy"},
+		},
+		{
+			name:    "unknown installation id",
+			config:  map[string]interface{}{"git_clone_strategy": "github_app", "github_installation_id": 123},
+			unknown: []string{"github_installation_id"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"project_id": 1,
+				"remote_url": "git@github.com:org/repo.git",
+			}
+			for key, value := range test.config {
+				config[key] = value
+			}
+
+			_, err := planResource(t, resourceRepository(), "", nil, config, nil, test.unknown...)
+
+			if test.expectedError == "" && err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if test.expectedError != "" && (err == nil || !strings.Contains(err.Error(), test.expectedError)) {
+				t.Fatalf("expected an error containing %q, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package dbt
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	dbtcredential "terraform-provider-dbt/dbt/credential"
+)
+
+func resourceSnowflakeCredential() *schema.Resource {
+	resource := resourceCredential(credentialAdapter{
+		toCredential: func(d *schema.ResourceData, credential *dbtcredential.Credential) {
+			credential.Type = "snowflake"
+			credential.User = d.Get("user").(string)
+			credential.Schema = d.Get("schema").(string)
+			credential.AuthType = d.Get("auth_type").(string)
+			credential.Password = d.Get("password").(string)
+			credential.PrivateKey = d.Get("private_key").(string)
+			credential.PrivateKeyPassphrase = d.Get("private_key_passphrase").(string)
+		},
+		setState: func(d *schema.ResourceData, credential *dbtcredential.Credential) {
+			d.Set("user", credential.User)
+			d.Set("schema", credential.Schema)
+			d.Set("auth_type", credential.AuthType)
+		},
+	}, map[string]*schema.Schema{
+		"user": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The Snowflake user dbt runs as",
+		},
+		"schema": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The schema dbt builds models in",
+		},
+		"auth_type": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          "password",
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"password", "keypair"}, false)),
+			Description:      "Either password or keypair. Defaults to password",
+		},
+		"password": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Sensitive:     true,
+			ConflictsWith: []string{"private_key"},
+			Description:   "The password of the user, required when auth_type is password. DBT never returns it, so it is kept in the terraform state as it was last written",
+		},
+		"private_key": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Sensitive:     true,
+			ConflictsWith: []string{"password"},
+			Description:   "The PEM encoded private key of the user, required when auth_type is keypair. DBT never returns it, so it is kept in the terraform state as it was last written",
+		},
+		"private_key_passphrase": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			RequiredWith: []string{"private_key"},
+			Description:  "The passphrase of an encrypted private_key. DBT never returns it, so it is kept in the terraform state as it was last written",
+		},
+	})
+
+	resource.CustomizeDiff = resourceSnowflakeCredentialCustomizeDiff
+
+	return resource
+}
+
+// resourceSnowflakeCredentialCustomizeDiff checks that the secret matching auth_type is configured.
+func resourceSnowflakeCredentialCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	secret := map[string]string{"password": "password", "keypair": "private_key"}[d.Get("auth_type").(string)]
+	if secret == "" {
+		return nil
+	}
+
+	if config.GetAttr(secret).IsNull() {
+		return fmt.Errorf("%s is required when auth_type is %s", secret, d.Get("auth_type"))
+	}
+
+	return nil
+}
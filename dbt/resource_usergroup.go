@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	"terraform-provider-dbt/dbt/client"
 	dbtusergroup "terraform-provider-dbt/dbt/user_group"
 	utils "terraform-provider-dbt/dbt/utils"
 )
@@ -20,6 +22,12 @@ func resourceUserUserGroup() *schema.Resource {
 		ReadContext:   resourceUserGroupRead,
 		UpdateContext: resourceUserGroupUpdate,
 		DeleteContext: resourceUserGroupDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -29,6 +37,17 @@ func resourceUserUserGroup() *schema.Resource {
 				Type:     schema.TypeBool,
 				Required: true,
 			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Set this to true to take over an existing group with the same name instead of creating a new one. The existing group must not have permissions or sso mappings that are missing from the configuration, unless manage_permissions is false for the permissions. An adopted group is not deleted when the resource is destroyed",
+			},
+			"adopted": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the group existed before and was adopted with adopt_existing. An adopted group is only removed from the state when the resource is destroyed",
+			},
 			"sso_mapping_groups": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -36,50 +55,22 @@ func resourceUserUserGroup() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"manage_permissions": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Set this to false to leave the permissions of the group alone, e.g. when they are granted with dbt_user_group_permission. group_permissions must then be empty. Defaults to true",
+			},
 			"group_permissions": {
-				Type:     schema.TypeSet,
-				Optional: true,
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The permissions of the group. The group gets exactly these permissions, all other permissions are revoked, unless manage_permissions is false",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"permission_set": {
-							Type:     schema.TypeString,
-							Required: true,
-							ValidateDiagFunc: func(i interface{}, p cty.Path) diag.Diagnostics {
-								value := i.(string)
-								var diags diag.Diagnostics
-
-								validUserGroupPermissions := []string{
-									"owner",
-									"member",
-									"account_admin",
-									"admin",
-									"database_admin",
-									"git_admin",
-									"team_admin",
-									"job_admin",
-									"job_viewer",
-									"analyst",
-									"developer",
-									"stakeholder",
-									"readonly",
-									"project_creator",
-									"account_viewer",
-									"metadata_only",
-									"webhooks_only",
-								}
-
-								for _, val := range validUserGroupPermissions {
-									if val == value {
-										return diags
-									}
-								}
-
-								return append(diags, diag.Diagnostic{
-									Severity: diag.Warning,
-									Summary:  "UserGroup Permission not valid",
-									Detail:   fmt.Sprintf("%q is not a valid group permission. Must be one of: [%s]", value, strings.Join(validUserGroupPermissions, ", ")),
-								})
-							},
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validateUserGroupPermissionSet,
 						},
 						"project_id": {
 							Type:     schema.TypeInt,
@@ -93,30 +84,149 @@ func resourceUserUserGroup() *schema.Resource {
 				},
 			},
 		},
+		CustomizeDiff: resourceUserGroupCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
 
+func validateUserGroupPermissionSet(i interface{}, p cty.Path) diag.Diagnostics {
+	value := i.(string)
+	var diags diag.Diagnostics
+
+	validUserGroupPermissions := []string{
+		"owner",
+		"member",
+		"account_admin",
+		"admin",
+		"database_admin",
+		"git_admin",
+		"team_admin",
+		"job_admin",
+		"job_viewer",
+		"analyst",
+		"developer",
+		"stakeholder",
+		"readonly",
+		"project_creator",
+		"account_viewer",
+		"metadata_only",
+		"webhooks_only",
+	}
+
+	for _, val := range validUserGroupPermissions {
+		if val == value {
+			return diags
+		}
+	}
+
+	return append(diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "UserGroup Permission not valid",
+		Detail:   fmt.Sprintf("%q is not a valid group permission. Must be one of: [%s]", value, strings.Join(validUserGroupPermissions, ", ")),
+	})
+}
+
+// resourceUserGroupCustomizeDiff rejects group_permissions blocks that DBT would reject, before the group is created.
+// The raw config is used, so that a project_id that is not known until apply still counts as set.
+func resourceUserGroupCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	permissions := config.GetAttr("group_permissions")
+	if permissions.IsNull() || !permissions.IsKnown() {
+		return nil
+	}
+
+	if d.NewValueKnown("manage_permissions") && !d.Get("manage_permissions").(bool) && permissions.LengthInt() > 0 {
+		return fmt.Errorf("group_permissions cannot be set when manage_permissions is false")
+	}
+
+	for it := permissions.ElementIterator(); it.Next(); {
+		_, permission := it.Element()
+		if permission.IsNull() || !permission.IsKnown() {
+			continue
+		}
+
+		allProjects := permission.GetAttr("all_projects")
+		if allProjects.IsNull() || !allProjects.IsKnown() {
+			continue
+		}
+
+		permissionSet := "(known after apply)"
+		if value := permission.GetAttr("permission_set"); value.IsKnown() && !value.IsNull() {
+			permissionSet = value.AsString()
+		}
+
+		hasProjectId := !permission.GetAttr("project_id").IsNull()
+
+		if allProjects.True() && hasProjectId {
+			return fmt.Errorf("the group_permissions block with permission_set %q has all_projects = true and a project_id. Remove project_id, or set all_projects = false", permissionSet)
+		}
+
+		if allProjects.False() && !hasProjectId {
+			return fmt.Errorf("the group_permissions block with permission_set %q has all_projects = false but no project_id. Set project_id, or set all_projects = true", permissionSet)
+		}
+	}
+
+	return nil
+}
+
 func resourceUserGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	providerInput := m.(*DbtProviderInput)
-	groupInput := readUserGroupFromResourceData(d, providerInput.AccountId)
+	c := m.(*client.Client)
+	groupInput := readUserGroupFromResourceData(d, c.AccountId)
+
+	var existingGroup *dbtusergroup.UserGroup
+	if d.Get("adopt_existing").(bool) {
+		var diags diag.Diagnostics
+		existingGroup, diags = dbtusergroup.FindUserGroupByName(ctx, c, c.AccountId, groupInput.Name)
+		if diags != nil {
+			return diags
+		}
+	}
+
+	var group *dbtusergroup.UserGroup
+	var diags diag.Diagnostics
+	if existingGroup != nil {
+		var managedPermissions *[]dbtusergroup.UserGroupPermission
+		if d.Get("manage_permissions").(bool) {
+			managedPermissions = readUserGroupPermissionsFromResourceData(d, existingGroup.Id, existingGroup.AccountId)
+		}
+
+		diags = dbtusergroup.CheckAdoptedUserGroup(existingGroup, groupInput, managedPermissions)
+		if diags != nil {
+			return diags
+		}
 
-	group, diags := dbtusergroup.CreateUserGroup(groupInput, providerInput.ServiceToken)
+		groupInput.Id = existingGroup.Id
+		group, diags = dbtusergroup.UpdateUserGroup(ctx, c, groupInput)
+	} else {
+		group, diags = dbtusergroup.CreateUserGroup(ctx, c, groupInput)
+	}
 	if diags != nil {
 		return diags
 	}
 
 	groupPermisisonsInput := readUserGroupPermissionsFromResourceData(d, group.Id, group.AccountId)
 
-	if group != nil {
-		setStateFromUserGroup(d, group)
+	setStateFromUserGroup(d, group)
+	d.Set("adopted", existingGroup != nil)
+
+	if !d.Get("manage_permissions").(bool) {
+		return nil
 	}
 
-	groupPermissions, diags := dbtusergroup.CreateOrUpdateUserGroupPermissions(groupPermisisonsInput, group.Id, group.AccountId, providerInput.ServiceToken)
+	groupPermissions, diags := dbtusergroup.CreateOrUpdateUserGroupPermissions(ctx, c, groupPermisisonsInput, group.Id, group.AccountId)
 	if diags != nil {
-		return diags
+		// An adopted group existed before terraform, so it is never deleted. It is adopted again on the next apply.
+		if existingGroup != nil {
+			d.SetId("")
+			return diags
+		}
+		return rollbackUserGroupCreate(ctx, c, d, group, diags)
 	}
 
 	d.Set("group_permissions", flattenUserGroupPermissions(groupPermissions))
@@ -124,11 +234,36 @@ func resourceUserGroupCreate(ctx context.Context, d *schema.ResourceData, m inte
 	return diags
 }
 
+// rollbackUserGroupCreate deletes a group whose permissions could not be set, so that a failed create does not
+// leave a group behind. If the delete also fails the id is kept, and terraform marks the group as tainted.
+func rollbackUserGroupCreate(ctx context.Context, c *client.Client, d *schema.ResourceData, group *dbtusergroup.UserGroup, diags diag.Diagnostics) diag.Diagnostics {
+	// The create may have failed because it was cancelled or timed out, the rollback still has to run.
+	if ctx.Err() != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+	}
+
+	rollbackDiags := dbtusergroup.DeleteUserGroup(ctx, c, group)
+	if rollbackDiags != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Could not delete the group after setting its permissions failed",
+			Detail:   fmt.Sprintf("The group %q (%d) was created, but is left without permissions. It will be replaced on the next apply", group.Name, group.Id),
+		})
+		return append(diags, rollbackDiags...)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
 func resourceUserGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	providerInput := m.(*DbtProviderInput)
-	groupInput := readUserGroupFromResourceData(d, providerInput.AccountId)
+	c := m.(*client.Client)
+	groupInput := readUserGroupFromResourceData(d, c.AccountId)
 
-	group, diags := dbtusergroup.ReadUserGroup(groupInput, providerInput.ServiceToken)
+	group, diags := dbtusergroup.ReadUserGroup(ctx, c, groupInput)
 
 	if diags != nil {
 		return diags
@@ -136,6 +271,11 @@ func resourceUserGroupRead(ctx context.Context, d *schema.ResourceData, m interf
 
 	if group != nil {
 		setStateFromUserGroup(d, group)
+		if readManagePermissions(d) {
+			d.Set("group_permissions", flattenUserGroupPermissions(group.UserGroupPermissions))
+		} else {
+			d.Set("group_permissions", nil)
+		}
 	} else {
 		d.SetId("")
 	}
@@ -143,15 +283,26 @@ func resourceUserGroupRead(ctx context.Context, d *schema.ResourceData, m interf
 	return diags
 }
 
+// readManagePermissions returns manage_permissions from the state. It is missing from imported groups and from states
+// written before it was added, which both manage the permissions of the group.
+func readManagePermissions(d *schema.ResourceData) bool {
+	if state := d.GetRawState(); !state.IsNull() && state.IsKnown() && state.GetAttr("manage_permissions").IsNull() {
+		d.Set("manage_permissions", true)
+		return true
+	}
+
+	return d.Get("manage_permissions").(bool)
+}
+
 func resourceUserGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	providerInput := m.(*DbtProviderInput)
-	groupInput := readUserGroupFromResourceData(d, providerInput.AccountId)
+	c := m.(*client.Client)
+	groupInput := readUserGroupFromResourceData(d, c.AccountId)
 	groupPermisisonsInput := readUserGroupPermissionsFromResourceData(d, groupInput.Id, groupInput.AccountId)
 
 	if groupHasChange(d) {
-		group, diags := dbtusergroup.UpdateUserGroup(groupInput, providerInput.ServiceToken)
+		group, diags := dbtusergroup.UpdateUserGroup(ctx, c, groupInput)
 		if group != nil {
 			setStateFromUserGroup(d, group)
 		}
@@ -160,10 +311,12 @@ func resourceUserGroupUpdate(ctx context.Context, d *schema.ResourceData, m inte
 		}
 	}
 
-	if d.HasChange("group_permissions") {
-		groupPermissions, diags := dbtusergroup.CreateOrUpdateUserGroupPermissions(groupPermisisonsInput, groupInput.Id, groupInput.AccountId, providerInput.ServiceToken)
+	if d.Get("manage_permissions").(bool) && d.HasChanges("group_permissions", "manage_permissions") {
+		groupPermissions, diags := dbtusergroup.CreateOrUpdateUserGroupPermissions(ctx, c, groupPermisisonsInput, groupInput.Id, groupInput.AccountId)
+		if diags != nil {
+			return diags
+		}
 		d.Set("group_permissions", flattenUserGroupPermissions(groupPermissions))
-		return diags
 	}
 
 	return diags
@@ -179,10 +332,16 @@ func groupHasChange(d *schema.ResourceData) bool {
 }
 
 func resourceUserGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	providerInput := m.(*DbtProviderInput)
-	groupInput := readUserGroupFromResourceData(d, providerInput.AccountId)
+	c := m.(*client.Client)
+	groupInput := readUserGroupFromResourceData(d, c.AccountId)
+
+	// An adopted group existed before terraform, so it is left in the account.
+	if d.Get("adopted").(bool) {
+		d.SetId("")
+		return nil
+	}
 
-	diags := dbtusergroup.DeleteUserGroup(groupInput, providerInput.ServiceToken)
+	diags := dbtusergroup.DeleteUserGroup(ctx, c, groupInput)
 
 	d.SetId("")
 
@@ -195,7 +354,6 @@ func setStateFromUserGroup(d *schema.ResourceData, group *dbtusergroup.UserGroup
 	d.Set("name", group.Name)
 	d.Set("account_id", group.AccountId)
 	d.Set("sso_mapping_groups", group.SsoMappingUserGroups)
-	d.Set("group_permissions", flattenUserGroupPermissions(group.UserGroupPermissions))
 }
 
 func readUserGroupFromResourceData(data *schema.ResourceData, accountId int) *dbtusergroup.UserGroup {
@@ -0,0 +1,189 @@
+package dbt
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"terraform-provider-dbt/dbt/client"
+	dbtusergroup "terraform-provider-dbt/dbt/user_group"
+)
+
+func resourceUserGroupPermission() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceUserGroupPermissionCreate,
+		ReadContext:   resourceUserGroupPermissionRead,
+		DeleteContext: resourceUserGroupPermissionDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Id of the group the permission is granted to",
+			},
+			"permission_set": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateUserGroupPermissionSet,
+				Description:      "The permission set to grant, e.g. developer or readonly",
+			},
+			"project_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Id of the project the permission is granted for. Must be set if all_projects is false, and must not be set if all_projects is true",
+			},
+			"all_projects": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Set this to true to grant the permission for all projects",
+			},
+		},
+		CustomizeDiff: resourceUserGroupPermissionCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceUserGroupPermissionImport,
+		},
+	}
+}
+
+func resourceUserGroupPermissionCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	allProjects := config.GetAttr("all_projects")
+	if !allProjects.IsKnown() {
+		return nil
+	}
+
+	hasProjectId := !config.GetAttr("project_id").IsNull()
+	isAllProjects := !allProjects.IsNull() && allProjects.True()
+
+	if isAllProjects && hasProjectId {
+		return fmt.Errorf("all_projects = true can not be combined with a project_id. Remove project_id, or set all_projects = false")
+	}
+
+	if !isAllProjects && !hasProjectId {
+		return fmt.Errorf("project_id must be set when all_projects is false. Set project_id, or set all_projects = true")
+	}
+
+	return nil
+}
+
+func resourceUserGroupPermissionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+	permissionInput := readUserGroupPermissionFromResourceData(d, c.AccountId)
+
+	permission, diags := dbtusergroup.AddUserGroupPermission(ctx, c, permissionInput)
+	if diags != nil {
+		return diags
+	}
+
+	setStateFromUserGroupPermission(d, permission)
+
+	return nil
+}
+
+func resourceUserGroupPermissionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+	permissionInput := readUserGroupPermissionFromResourceData(d, c.AccountId)
+
+	permission, diags := dbtusergroup.ReadUserGroupPermission(ctx, c, permissionInput)
+	if diags != nil {
+		return diags
+	}
+
+	if permission != nil {
+		setStateFromUserGroupPermission(d, permission)
+	} else {
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceUserGroupPermissionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+	permissionInput := readUserGroupPermissionFromResourceData(d, c.AccountId)
+
+	diags := dbtusergroup.RemoveUserGroupPermission(ctx, c, permissionInput)
+
+	if diags == nil {
+		d.SetId("")
+	}
+
+	return diags
+}
+
+// resourceUserGroupPermissionImport accepts ids formatted as group_id:permission_set:project_id,
+// where project_id is "all" for permissions on all projects.
+func resourceUserGroupPermissionImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 3 || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected group_id:permission_set:project_id or group_id:permission_set:all", d.Id())
+	}
+
+	groupId, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("unexpected format of ID (%s), group_id must be a number", d.Id())
+	}
+
+	d.Set("group_id", groupId)
+	d.Set("permission_set", parts[1])
+
+	if parts[2] == "all" {
+		d.Set("all_projects", true)
+	} else {
+		projectId, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("unexpected format of ID (%s), project_id must be a number or all", d.Id())
+		}
+		d.Set("all_projects", false)
+		d.Set("project_id", projectId)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func setStateFromUserGroupPermission(d *schema.ResourceData, permission *dbtusergroup.UserGroupPermission) {
+	project := "all"
+	if !permission.AllProjects {
+		project = strconv.Itoa(permission.ProjectId)
+	}
+
+	d.SetId(fmt.Sprintf("%d:%s:%s", permission.UserGroupId, permission.PermissionSet, project))
+	d.Set("group_id", permission.UserGroupId)
+	d.Set("permission_set", permission.PermissionSet)
+	d.Set("project_id", permission.ProjectId)
+	d.Set("all_projects", permission.AllProjects)
+}
+
+func readUserGroupPermissionFromResourceData(data *schema.ResourceData, accountId int) dbtusergroup.UserGroupPermission {
+	permission := dbtusergroup.UserGroupPermission{
+		UserGroupId:   data.Get("group_id").(int),
+		AccountId:     accountId,
+		PermissionSet: data.Get("permission_set").(string),
+		ProjectId:     data.Get("project_id").(int),
+		AllProjects:   data.Get("all_projects").(bool),
+	}
+
+	if permission.AllProjects {
+		permission.ProjectId = 0
+	}
+
+	return permission
+}
@@ -0,0 +1,463 @@
+package dbt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"terraform-provider-dbt/dbt/client"
+	dbtusergroup "terraform-provider-dbt/dbt/user_group"
+)
+
+// fakeUserGroups serves the groups of account 1. Setting the permissions of a group, or deleting a group, fails with
+// a bad request if failPermissions or failDelete is set. Like DBT sometimes does, the group endpoint leaves out the
+// permissions if omitPermissions is set, only the list endpoint returns them.
+type fakeUserGroups struct {
+	groups          map[int]*dbtusergroup.UserGroup
+	nextId          int
+	failPermissions bool
+	failDelete      bool
+	omitPermissions bool
+	deleted         []int
+}
+
+func newFakeUserGroups(groups ...dbtusergroup.UserGroup) *fakeUserGroups {
+	f := &fakeUserGroups{groups: map[int]*dbtusergroup.UserGroup{}, nextId: 100}
+	for i := range groups {
+		f.groups[groups[i].Id] = &groups[i]
+	}
+	return f
+}
+
+func (f *fakeUserGroups) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var id int
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v3/accounts/1/groups/":
+		groups := []dbtusergroup.UserGroup{}
+		for _, group := range f.groups {
+			groups = append(groups, *group)
+		}
+		json.NewEncoder(w).Encode(client.Response[[]dbtusergroup.UserGroup]{Data: groups})
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v3/accounts/1/groups/":
+		var group dbtusergroup.UserGroup
+		json.NewDecoder(r.Body).Decode(&group)
+		group.Id = f.nextId
+		group.State = client.StateActive
+		f.nextId++
+		f.groups[group.Id] = &group
+		json.NewEncoder(w).Encode(client.Response[dbtusergroup.UserGroup]{Data: group})
+	case r.Method == http.MethodGet && scanPath(r.URL.Path, "/api/v3/accounts/1/groups/%d/", &id) && f.groups[id] != nil:
+		group := *f.groups[id]
+		if f.omitPermissions {
+			group.UserGroupPermissions = nil
+		}
+		json.NewEncoder(w).Encode(client.Response[dbtusergroup.UserGroup]{Data: group})
+	case r.Method == http.MethodPost && scanPath(r.URL.Path, "/api/v3/accounts/1/groups/%d/", &id) && f.groups[id] != nil:
+		var group dbtusergroup.UserGroup
+		json.NewDecoder(r.Body).Decode(&group)
+		if group.State == client.StateDeleted {
+			if f.failDelete {
+				http.Error(w, "could not delete the group", http.StatusBadRequest)
+				return
+			}
+			f.deleted = append(f.deleted, id)
+		}
+		if group.State == 0 {
+			group.State = f.groups[id].State
+		}
+		group.Id = id
+		group.UserGroupPermissions = f.groups[id].UserGroupPermissions
+		f.groups[id] = &group
+		json.NewEncoder(w).Encode(client.Response[dbtusergroup.UserGroup]{Data: group})
+	case r.Method == http.MethodPost && scanPath(r.URL.Path, "/api/v3/accounts/1/group-permissions/%d/", &id) && f.groups[id] != nil:
+		if f.failPermissions {
+			http.Error(w, "invalid permission set", http.StatusBadRequest)
+			return
+		}
+		var permissions []dbtusergroup.UserGroupPermission
+		json.NewDecoder(r.Body).Decode(&permissions)
+		f.groups[id].UserGroupPermissions = &permissions
+		json.NewEncoder(w).Encode(client.Response[[]dbtusergroup.UserGroupPermission]{Data: permissions})
+	default:
+		http.Error(w, fmt.Sprintf("unexpected request %s %s", r.Method, r.URL.Path), http.StatusNotFound)
+	}
+}
+
+func scanPath(path string, format string, id *int) bool {
+	n, err := fmt.Sscanf(path, format, id)
+	return err == nil && n == 1 && fmt.Sprintf(format, *id) == path
+}
+
+func TestResourceUserGroupManagePermissions(t *testing.T) {
+	readonly := map[string]interface{}{"permission_set": "readonly", "all_projects": true}
+
+	tests := []struct {
+		name          string
+		config        map[string]interface{}
+		expectedError string
+	}{
+		{
+			name:   "managed permissions",
+			config: map[string]interface{}{"group_permissions": []interface{}{readonly}},
+		},
+		{
+			name:   "unmanaged permissions",
+			config: map[string]interface{}{"manage_permissions": false},
+		},
+		{
+			name:          "unmanaged permissions with group_permissions",
+			config:        map[string]interface{}{"manage_permissions": false, "group_permissions": []interface{}{readonly}},
+			expectedError: "group_permissions cannot be set when manage_permissions is false",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := map[string]interface{}{"name": "group", "assign_by_default": false}
+			for key, value := range test.config {
+				config[key] = value
+			}
+
+			_, err := planResource(t, resourceUserUserGroup(), "", nil, config, nil)
+
+			if test.expectedError == "" && err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if test.expectedError != "" && (err == nil || !strings.Contains(err.Error(), test.expectedError)) {
+				t.Fatalf("expected an error containing %q, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestResourceUserGroupPermissionValidation(t *testing.T) {
+	tests := []struct {
+		name          string
+		permission    map[string]interface{}
+		unknown       []string
+		expectedError string
+	}{
+		{
+			name:       "all projects",
+			permission: map[string]interface{}{"permission_set": "readonly", "all_projects": true},
+		},
+		{
+			name:       "single project",
+			permission: map[string]interface{}{"permission_set": "analyst", "project_id": 42, "all_projects": false},
+		},
+		{
+			name:          "all projects with project_id",
+			permission:    map[string]interface{}{"permission_set": "readonly", "project_id": 42, "all_projects": true},
+			expectedError: `the group_permissions block with permission_set "readonly" has all_projects = true and a project_id`,
+		},
+		{
+			name:          "single project without project_id",
+			permission:    map[string]interface{}{"permission_set": "analyst", "all_projects": false},
+			expectedError: `the group_permissions block with permission_set "analyst" has all_projects = false but no project_id`,
+		},
+		{
+			name:       "unknown project_id",
+			permission: map[string]interface{}{"permission_set": "analyst", "project_id": 42, "all_projects": false},
+			unknown:    []string{"project_id"},
+		},
+		{
+			name:          "all projects with unknown project_id",
+			permission:    map[string]interface{}{"permission_set": "readonly", "project_id": 42, "all_projects": true},
+			unknown:       []string{"project_id"},
+			expectedError: `the group_permissions block with permission_set "readonly" has all_projects = true and a project_id`,
+		},
+		{
+			name:       "unknown all_projects",
+			permission: map[string]interface{}{"permission_set": "analyst", "all_projects": false},
+			unknown:    []string{"all_projects"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"name":              "group",
+				"assign_by_default": false,
+				"group_permissions": []interface{}{test.permission},
+			}
+
+			_, err := planResource(t, resourceUserUserGroup(), "", nil, config, nil, test.unknown...)
+
+			if test.expectedError == "" && err != nil {
+				t.Fatalf("expected no error, got %s", err)
+			}
+
+			if test.expectedError != "" && (err == nil || !strings.Contains(err.Error(), test.expectedError)) {
+				t.Fatalf("expected an error containing %q, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestResourceUserGroupRemoveLastPermission(t *testing.T) {
+	attributes := map[string]string{
+		"id":                                 "7",
+		"name":                               "group",
+		"assign_by_default":                  "false",
+		"adopt_existing":                     "false",
+		"manage_permissions":                 "true",
+		"sso_mapping_groups.#":               "0",
+		"group_permissions.#":                "1",
+		"group_permissions.1.permission_set": "readonly",
+		"group_permissions.1.project_id":     "0",
+		"group_permissions.1.all_projects":   "true",
+	}
+	config := map[string]interface{}{"name": "group", "assign_by_default": false}
+
+	diff, err := planResource(t, resourceUserUserGroup(), "7", attributes, config, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if diff == nil || diff.Attributes["group_permissions.#"] == nil || diff.Attributes["group_permissions.#"].New != "0" {
+		t.Fatalf("expected the permissions to be removed, got %v", diff)
+	}
+}
+
+func TestResourceUserGroupDestroyAdopted(t *testing.T) {
+	tests := []struct {
+		name            string
+		adoptExisting   bool
+		expectedId      string
+		expectedDeleted bool
+	}{
+		{name: "adopted group is kept", adoptExisting: true, expectedId: "3", expectedDeleted: false},
+		{name: "created group is deleted", adoptExisting: false, expectedId: "100", expectedDeleted: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fake := newFakeUserGroups(dbtusergroup.UserGroup{Id: 3, AccountId: 1, Name: "Everyone", State: client.StateActive})
+			server := httptest.NewServer(fake)
+			defer server.Close()
+			c := client.NewClient(server.URL, "token", 1)
+
+			r := resourceUserUserGroup()
+			d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+				"name":              "Everyone",
+				"assign_by_default": true,
+				"adopt_existing":    test.adoptExisting,
+			})
+
+			if diags := resourceUserGroupCreate(context.Background(), d, c); diags.HasError() {
+				t.Fatalf("expected no error, got %v", diags)
+			}
+
+			if d.Id() != test.expectedId || d.Get("adopted").(bool) != test.adoptExisting {
+				t.Fatalf("expected id %s and adopted %v, got %s and %v", test.expectedId, test.adoptExisting, d.Id(), d.Get("adopted"))
+			}
+
+			if diags := resourceUserGroupDelete(context.Background(), d, c); diags.HasError() {
+				t.Fatalf("expected no error, got %v", diags)
+			}
+
+			if d.Id() != "" {
+				t.Errorf("expected the id to be cleared, got %s", d.Id())
+			}
+
+			if deleted := len(fake.deleted) > 0; deleted != test.expectedDeleted {
+				t.Errorf("expected deleted to be %v, got %v", test.expectedDeleted, fake.deleted)
+			}
+		})
+	}
+}
+
+func TestResourceUserGroupCreateRollback(t *testing.T) {
+	tests := []struct {
+		name              string
+		adoptExisting     bool
+		failDelete        bool
+		expectedId        string
+		expectedDeleted   bool
+		expectedSummaries []string
+	}{
+		{
+			name:              "created group is deleted",
+			expectedId:        "",
+			expectedDeleted:   true,
+			expectedSummaries: []string{"Dbt returned an error in CreateOrUpdatePermissions"},
+		},
+		{
+			name:            "delete fails",
+			failDelete:      true,
+			expectedId:      "100",
+			expectedDeleted: false,
+			expectedSummaries: []string{
+				"Dbt returned an error in CreateOrUpdatePermissions",
+				"Could not delete the group after setting its permissions failed",
+				"Dbt returned an error in DeleteUserGroup",
+			},
+		},
+		{
+			name:              "adopted group is kept",
+			adoptExisting:     true,
+			expectedId:        "",
+			expectedDeleted:   false,
+			expectedSummaries: []string{"Dbt returned an error in CreateOrUpdatePermissions"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fake := newFakeUserGroups(dbtusergroup.UserGroup{Id: 3, AccountId: 1, Name: "Analysts", State: client.StateActive})
+			fake.failPermissions = true
+			fake.failDelete = test.failDelete
+			server := httptest.NewServer(fake)
+			defer server.Close()
+
+			d := schema.TestResourceDataRaw(t, resourceUserUserGroup().Schema, map[string]interface{}{
+				"name":              "Analysts",
+				"assign_by_default": false,
+				"adopt_existing":    test.adoptExisting,
+				"group_permissions": []interface{}{map[string]interface{}{"permission_set": "analyst", "all_projects": true}},
+			})
+
+			diags := resourceUserGroupCreate(context.Background(), d, client.NewClient(server.URL, "token", 1))
+
+			var summaries []string
+			for _, diagnostic := range diags {
+				summaries = append(summaries, diagnostic.Summary)
+			}
+			if strings.Join(summaries, "\n") != strings.Join(test.expectedSummaries, "\n") {
+				t.Errorf("expected diagnostics %q, got %q", test.expectedSummaries, summaries)
+			}
+
+			if d.Id() != test.expectedId {
+				t.Errorf("expected id %q, got %q", test.expectedId, d.Id())
+			}
+
+			if deleted := len(fake.deleted) > 0; deleted != test.expectedDeleted {
+				t.Errorf("expected deleted to be %v, got %v", test.expectedDeleted, fake.deleted)
+			}
+
+			if fake.groups[3].State != client.StateActive {
+				t.Errorf("expected the existing group to stay active, got state %d", fake.groups[3].State)
+			}
+		})
+	}
+}
+
+func TestResourceUserGroupAdoptChecks(t *testing.T) {
+	readonly := map[string]interface{}{"permission_set": "readonly", "all_projects": true}
+
+	tests := []struct {
+		name          string
+		config        map[string]interface{}
+		expectedError string
+	}{
+		{
+			name:          "permissions would be revoked",
+			config:        map[string]interface{}{"sso_mapping_groups": []interface{}{"analysts"}},
+			expectedError: "Adopting the group would revoke its permissions",
+		},
+		{
+			name:          "sso mappings would be removed",
+			config:        map[string]interface{}{"group_permissions": []interface{}{readonly}},
+			expectedError: "Adopting the group would remove its sso mappings",
+		},
+		{
+			name:   "configuration contains everything",
+			config: map[string]interface{}{"group_permissions": []interface{}{readonly}, "sso_mapping_groups": []interface{}{"analysts"}},
+		},
+		{
+			name:   "unmanaged permissions",
+			config: map[string]interface{}{"manage_permissions": false, "sso_mapping_groups": []interface{}{"analysts"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// The all projects permission is returned with a project id, which does not count as a difference.
+			fake := newFakeUserGroups(dbtusergroup.UserGroup{
+				Id:                   3,
+				AccountId:            1,
+				Name:                 "Analysts",
+				State:                client.StateActive,
+				SsoMappingUserGroups: []string{"analysts"},
+				UserGroupPermissions: &[]dbtusergroup.UserGroupPermission{{UserGroupId: 3, AccountId: 1, PermissionSet: "readonly", AllProjects: true, ProjectId: 42}},
+			})
+			server := httptest.NewServer(fake)
+			defer server.Close()
+
+			config := map[string]interface{}{"name": "Analysts", "assign_by_default": false, "adopt_existing": true}
+			for key, value := range test.config {
+				config[key] = value
+			}
+			d := schema.TestResourceDataRaw(t, resourceUserUserGroup().Schema, config)
+
+			diags := resourceUserGroupCreate(context.Background(), d, client.NewClient(server.URL, "token", 1))
+
+			if test.expectedError == "" && diags.HasError() {
+				t.Fatalf("expected no error, got %v", diags)
+			}
+
+			if test.expectedError != "" && (len(diags) != 1 || diags[0].Summary != test.expectedError) {
+				t.Fatalf("expected the error %q, got %v", test.expectedError, diags)
+			}
+
+			if test.expectedError != "" && (d.Id() != "" || len(fake.groups[3].SsoMappingUserGroups) != 1 || len(*fake.groups[3].UserGroupPermissions) != 1) {
+				t.Errorf("expected the group to be left alone, got %+v", fake.groups[3])
+			}
+		})
+	}
+}
+
+func TestResourceUserGroupReadNormalizesPermissions(t *testing.T) {
+	fake := newFakeUserGroups(dbtusergroup.UserGroup{
+		Id:        3,
+		AccountId: 1,
+		Name:      "Analysts",
+		State:     client.StateActive,
+		UserGroupPermissions: &[]dbtusergroup.UserGroupPermission{
+			{UserGroupId: 3, AccountId: 1, PermissionSet: "readonly", AllProjects: true, ProjectId: 99, State: client.StateActive},
+			{UserGroupId: 3, AccountId: 1, PermissionSet: "analyst", ProjectId: 42, State: client.StateActive},
+			{UserGroupId: 3, AccountId: 1, PermissionSet: "developer", ProjectId: 42, State: client.StateDeleted},
+		},
+	})
+	fake.omitPermissions = true
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	c := client.NewClient(server.URL, "token", 1)
+
+	config := map[string]interface{}{
+		"name":              "Analysts",
+		"assign_by_default": false,
+		"group_permissions": []interface{}{
+			map[string]interface{}{"permission_set": "readonly", "all_projects": true},
+			map[string]interface{}{"permission_set": "analyst", "project_id": 42, "all_projects": false},
+		},
+	}
+
+	r := resourceUserUserGroup()
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	d.SetId("3")
+
+	if diags := resourceUserGroupRead(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("expected no error, got %v", diags)
+	}
+
+	if d.Get("group_permissions").(*schema.Set).Len() != 2 {
+		t.Fatalf("expected the deleted permission to be left out, got %v", d.Get("group_permissions"))
+	}
+
+	diff, err := planResource(t, r, "3", d.State().Attributes, config, c)
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no changes, got %v", diff)
+	}
+}
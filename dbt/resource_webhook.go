@@ -0,0 +1,163 @@
+package dbt
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"terraform-provider-dbt/dbt/client"
+	utils "terraform-provider-dbt/dbt/utils"
+	dbtwebhook "terraform-provider-dbt/dbt/webhook"
+)
+
+func resourceWebhook() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceWebhookCreate,
+		ReadContext:   resourceWebhookRead,
+		UpdateContext: resourceWebhookUpdate,
+		DeleteContext: resourceWebhookDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the webhook",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the webhook",
+			},
+			"client_url": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPS),
+				Description:      "The https url the events are sent to",
+			},
+			"event_types": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "The events sent to the webhook: job.run.started, job.run.completed and/or job.run.errored",
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(dbtwebhook.EventTypes, false)),
+				},
+			},
+			"job_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Ids of the jobs to send events for. Events of all jobs are sent if not set",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Set this to false to stop sending events without deleting the webhook",
+			},
+			"hmac_secret": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The secret DBT signs the events with, to verify the Authorization header of the requests",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceWebhookCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	webhook, diags := dbtwebhook.CreateWebhook(ctx, c, readWebhookFromResourceData(d, c.AccountId))
+	if diags != nil {
+		return diags
+	}
+
+	setStateFromWebhook(d, webhook)
+
+	return nil
+}
+
+func resourceWebhookRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	webhook, diags := dbtwebhook.ReadWebhook(ctx, c, c.AccountId, d.Id())
+	if diags != nil {
+		return diags
+	}
+
+	if webhook != nil {
+		setStateFromWebhook(d, webhook)
+	} else {
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceWebhookUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	webhook, diags := dbtwebhook.UpdateWebhook(ctx, c, readWebhookFromResourceData(d, c.AccountId))
+	if diags != nil {
+		return diags
+	}
+
+	setStateFromWebhook(d, webhook)
+
+	return nil
+}
+
+func resourceWebhookDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.Client)
+
+	diags := dbtwebhook.DeleteWebhook(ctx, c, c.AccountId, d.Id())
+
+	if diags == nil {
+		d.SetId("")
+	}
+
+	return diags
+}
+
+func setStateFromWebhook(d *schema.ResourceData, webhook *dbtwebhook.Webhook) {
+	d.SetId(webhook.Id)
+	d.Set("name", webhook.Name)
+	d.Set("description", webhook.Description)
+	d.Set("client_url", webhook.ClientUrl)
+	d.Set("event_types", webhook.EventTypes)
+	d.Set("job_ids", webhook.JobIds)
+	d.Set("active", webhook.Active)
+
+	// Keep the secret from state if DBT leaves it out of the response.
+	if webhook.HmacSecret != "" {
+		d.Set("hmac_secret", webhook.HmacSecret)
+	}
+}
+
+func readWebhookFromResourceData(data *schema.ResourceData, accountId int) *dbtwebhook.Webhook {
+	return &dbtwebhook.Webhook{
+		Id:          data.Id(),
+		AccountId:   accountId,
+		Name:        data.Get("name").(string),
+		Description: data.Get("description").(string),
+		ClientUrl:   data.Get("client_url").(string),
+		EventTypes:  utils.InterfaceToStringList(data.Get("event_types")),
+		JobIds:      utils.InterfaceListToIntList(data.Get("job_ids").(*schema.Set).List()),
+		Active:      data.Get("active").(bool),
+	}
+}
@@ -1,43 +1,208 @@
 package dbtusergroup
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
-	"net/http"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"terraform-provider-dbt/dbt/client"
 )
 
-func CreateOrUpdateUserGroupPermissions(groupPermissionsInput *[]UserGroupPermission, groupId int, accountId int, serviceToken string) (*[]UserGroupPermission, diag.Diagnostics) {
-	url := fmt.Sprintf("https://cloud.getdbt.com/api/v3/accounts/%d/group-permissions/%d/", accountId, groupId)
+var permissionLocksLock sync.Mutex
 
-	response, err := PostAsJson(groupPermissionsInput, url, serviceToken)
+// permissionLocks serializes changes to the permissions of a group, since DBT only supports replacing
+// the complete list. The locks are channels so that waiting for them can be cancelled.
+var permissionLocks = map[int]chan struct{}{}
 
-	if err != nil {
-		return nil, diag.FromErr(err)
+func lockGroupPermissions(ctx context.Context, groupId int) (func(), diag.Diagnostics) {
+	permissionLocksLock.Lock()
+	lock, ok := permissionLocks[groupId]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		permissionLocks[groupId] = lock
 	}
+	permissionLocksLock.Unlock()
 
-	data, _ := ioutil.ReadAll(response.Body)
-	defer response.Body.Close()
+	select {
+	case lock <- struct{}{}:
+		return func() { <-lock }, nil
+	case <-ctx.Done():
+		return nil, diag.FromErr(ctx.Err())
+	}
+}
 
-	if response.StatusCode != http.StatusOK {
-		return nil, diag.Diagnostics{diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Dbt returned an http error code in CreateOrUpdatePermissions",
-			Detail:   fmt.Errorf("Returned statusCode %d for %s, message: %s", response.StatusCode, url, data).Error(),
-		}}
+func CreateOrUpdateUserGroupPermissions(ctx context.Context, c *client.Client, groupPermissionsInput *[]UserGroupPermission, groupId int, accountId int) (*[]UserGroupPermission, diag.Diagnostics) {
+	unlock, diags := lockGroupPermissions(ctx, groupId)
+	if diags != nil {
+		return nil, diags
+	}
+	defer unlock()
+
+	return postUserGroupPermissions(ctx, c, groupPermissionsInput, groupId, accountId)
+}
+
+// permissionWriteAttempts is how often a single permission is written before giving up. Locking only works within
+// one provider process, so another workspace changing the same group can overwrite the write with a stale list.
+const permissionWriteAttempts = 3
+
+// AddUserGroupPermission adds a single permission to the group, keeping the permissions that are already there.
+// The permissions are read back after every write, and the permission is written again if a concurrent change
+// of the group removed it.
+func AddUserGroupPermission(ctx context.Context, c *client.Client, permission UserGroupPermission) (*UserGroupPermission, diag.Diagnostics) {
+	unlock, diags := lockGroupPermissions(ctx, permission.UserGroupId)
+	if diags != nil {
+		return nil, diags
+	}
+	defer unlock()
+
+	for attempt := 0; ; attempt++ {
+		existingPermissions, diags := readUserGroupPermissions(ctx, c, permission.AccountId, permission.UserGroupId)
+		if diags != nil {
+			return nil, diags
+		}
+
+		if existingPermissions == nil {
+			return nil, diag.Diagnostics{diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "The group does not exist",
+				Detail:   fmt.Sprintf("Could not add the %q permission, group %d does not exist", permission.PermissionSet, permission.UserGroupId),
+			}}
+		}
+
+		if existing := findUserGroupPermission(existingPermissions, permission); existing != nil {
+			return existing, nil
+		}
+
+		if attempt == permissionWriteAttempts {
+			return nil, diag.Diagnostics{diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Dbt did not add the group permission",
+				Detail:   fmt.Sprintf("The %q permission was sent to group %d %d times, but was missing every time the permissions were read back. Another workspace may be changing the permissions of the group at the same time", permission.PermissionSet, permission.UserGroupId, permissionWriteAttempts),
+			}}
+		}
+
+		groupPermissionsInput := append(append([]UserGroupPermission{}, *existingPermissions...), permission)
+
+		_, diags = postUserGroupPermissions(ctx, c, &groupPermissionsInput, permission.UserGroupId, permission.AccountId)
+		if diags != nil {
+			return nil, diags
+		}
+	}
+}
+
+// RemoveUserGroupPermission removes a single permission from the group, keeping all other permissions. Like
+// AddUserGroupPermission, the permissions are read back and the removal is retried if a concurrent change undid it.
+func RemoveUserGroupPermission(ctx context.Context, c *client.Client, permission UserGroupPermission) diag.Diagnostics {
+	unlock, diags := lockGroupPermissions(ctx, permission.UserGroupId)
+	if diags != nil {
+		return diags
+	}
+	defer unlock()
+
+	for attempt := 0; ; attempt++ {
+		existingPermissions, diags := readUserGroupPermissions(ctx, c, permission.AccountId, permission.UserGroupId)
+		if diags != nil || existingPermissions == nil {
+			return diags
+		}
+
+		groupPermissionsInput := []UserGroupPermission{}
+		for _, existing := range *existingPermissions {
+			if !sameUserGroupPermission(existing, permission) {
+				groupPermissionsInput = append(groupPermissionsInput, existing)
+			}
+		}
+
+		if len(groupPermissionsInput) == len(*existingPermissions) {
+			return nil
+		}
+
+		if attempt == permissionWriteAttempts {
+			return diag.Diagnostics{diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Dbt did not remove the group permission",
+				Detail:   fmt.Sprintf("The %q permission was removed from group %d %d times, but was back every time the permissions were read back. Another workspace may be changing the permissions of the group at the same time", permission.PermissionSet, permission.UserGroupId, permissionWriteAttempts),
+			}}
+		}
+
+		_, diags = postUserGroupPermissions(ctx, c, &groupPermissionsInput, permission.UserGroupId, permission.AccountId)
+		if diags != nil {
+			return diags
+		}
+	}
+}
+
+// ReadUserGroupPermission returns the permission if the group has it, or nil if the group or the permission is gone.
+func ReadUserGroupPermission(ctx context.Context, c *client.Client, permission UserGroupPermission) (*UserGroupPermission, diag.Diagnostics) {
+	existingPermissions, diags := readUserGroupPermissions(ctx, c, permission.AccountId, permission.UserGroupId)
+	if diags != nil || existingPermissions == nil {
+		return nil, diags
+	}
+
+	return findUserGroupPermission(existingPermissions, permission), nil
+}
+
+// readUserGroupPermissions returns nil if the group does not exist.
+func readUserGroupPermissions(ctx context.Context, c *client.Client, accountId int, groupId int) (*[]UserGroupPermission, diag.Diagnostics) {
+	group, diags := ReadUserGroup(ctx, c, &UserGroup{Id: groupId, AccountId: accountId})
+	if diags != nil || group == nil {
+		return nil, diags
+	}
+
+	return group.UserGroupPermissions, nil
+}
+
+func findUserGroupPermission(groupPermissions *[]UserGroupPermission, permission UserGroupPermission) *UserGroupPermission {
+	for _, existing := range *groupPermissions {
+		if sameUserGroupPermission(existing, permission) {
+			return &existing
+		}
 	}
 
-	var groupPermissionsResponse UserGroupPermissionsResponse
-	err = json.Unmarshal(data, &groupPermissionsResponse)
+	return nil
+}
+
+func sameUserGroupPermission(a UserGroupPermission, b UserGroupPermission) bool {
+	return a.PermissionSet == b.PermissionSet &&
+		a.AllProjects == b.AllProjects &&
+		(a.AllProjects || a.ProjectId == b.ProjectId)
+}
+
+func postUserGroupPermissions(ctx context.Context, c *client.Client, groupPermissionsInput *[]UserGroupPermission, groupId int, accountId int) (*[]UserGroupPermission, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/group-permissions/%d/", accountId, groupId)
+
+	groupPermissions, err := client.Post[[]UserGroupPermission](ctx, c, path, groupPermissionsInput)
 	if err != nil {
 		return nil, diag.Diagnostics{diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  "Could not parse Dbt groupPermissions response",
+			Summary:  "Dbt returned an error in CreateOrUpdatePermissions",
 			Detail:   err.Error(),
 		}}
 	}
 
-	return &groupPermissionsResponse.Data, nil
+	return normalizeUserGroupPermissions(groupPermissions), nil
+}
+
+// normalizeUserGroupPermissions makes the permissions from DBT comparable with the configuration:
+// deleted permissions are removed, and permissions for all projects never have a project id.
+func normalizeUserGroupPermissions(groupPermissions *[]UserGroupPermission) *[]UserGroupPermission {
+	normalized := []UserGroupPermission{}
+	if groupPermissions == nil {
+		return &normalized
+	}
+
+	for _, permission := range *groupPermissions {
+		if permission.State == client.StateDeleted {
+			continue
+		}
+
+		if permission.AllProjects {
+			permission.ProjectId = 0
+		}
+
+		normalized = append(normalized, permission)
+	}
+
+	return &normalized
 }
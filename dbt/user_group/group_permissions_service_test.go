@@ -0,0 +1,123 @@
+package dbtusergroup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"terraform-provider-dbt/dbt/client"
+)
+
+// fakeGroupPermissions serves the permissions of group 7. The first lostWrites writes are overwritten right away,
+// like a concurrent write from another workspace with a stale list of permissions would.
+type fakeGroupPermissions struct {
+	permissions []UserGroupPermission
+	lostWrites  int
+	writes      int
+}
+
+func (f *fakeGroupPermissions) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v3/accounts/1/groups/7/":
+		json.NewEncoder(w).Encode(client.Response[UserGroup]{Data: UserGroup{Id: 7, AccountId: 1, State: client.StateActive, UserGroupPermissions: &f.permissions}})
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v3/accounts/1/group-permissions/7/":
+		var permissions []UserGroupPermission
+		json.NewDecoder(r.Body).Decode(&permissions)
+
+		f.writes++
+		if f.writes > f.lostWrites {
+			f.permissions = permissions
+		}
+
+		json.NewEncoder(w).Encode(client.Response[[]UserGroupPermission]{Data: permissions})
+	default:
+		http.Error(w, fmt.Sprintf("unexpected request %s %s", r.Method, r.URL.Path), http.StatusNotFound)
+	}
+}
+
+func TestAddUserGroupPermission(t *testing.T) {
+	readonly := UserGroupPermission{UserGroupId: 7, AccountId: 1, PermissionSet: "readonly", AllProjects: true}
+	analyst := UserGroupPermission{UserGroupId: 7, AccountId: 1, PermissionSet: "analyst", ProjectId: 42}
+
+	tests := []struct {
+		name           string
+		lostWrites     int
+		existing       []UserGroupPermission
+		expectedWrites int
+		expectError    bool
+	}{
+		{name: "adds the permission", existing: []UserGroupPermission{readonly}, expectedWrites: 1},
+		{name: "permission already exists", existing: []UserGroupPermission{readonly, analyst}, expectedWrites: 0},
+		{name: "write lost to a concurrent change", lostWrites: 1, existing: []UserGroupPermission{readonly}, expectedWrites: 2},
+		{name: "every write lost", lostWrites: 10, existing: []UserGroupPermission{readonly}, expectedWrites: permissionWriteAttempts, expectError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fake := &fakeGroupPermissions{permissions: test.existing, lostWrites: test.lostWrites}
+			server := httptest.NewServer(fake)
+			defer server.Close()
+
+			permission, diags := AddUserGroupPermission(context.Background(), client.NewClient(server.URL, "token", 1), analyst)
+
+			if diags.HasError() != test.expectError {
+				t.Fatalf("expected error to be %v, got %v", test.expectError, diags)
+			}
+
+			if !test.expectError && (permission == nil || !sameUserGroupPermission(*permission, analyst)) {
+				t.Errorf("expected the analyst permission to be returned, got %v", permission)
+			}
+
+			if !test.expectError && findUserGroupPermission(&fake.permissions, readonly) == nil {
+				t.Errorf("expected the existing permission to be kept, got %v", fake.permissions)
+			}
+
+			if fake.writes != test.expectedWrites {
+				t.Errorf("expected %d writes, got %d", test.expectedWrites, fake.writes)
+			}
+		})
+	}
+}
+
+func TestRemoveUserGroupPermission(t *testing.T) {
+	readonly := UserGroupPermission{UserGroupId: 7, AccountId: 1, PermissionSet: "readonly", AllProjects: true}
+	analyst := UserGroupPermission{UserGroupId: 7, AccountId: 1, PermissionSet: "analyst", ProjectId: 42}
+
+	tests := []struct {
+		name           string
+		lostWrites     int
+		existing       []UserGroupPermission
+		expectedWrites int
+		expectError    bool
+	}{
+		{name: "removes the permission", existing: []UserGroupPermission{readonly, analyst}, expectedWrites: 1},
+		{name: "permission already gone", existing: []UserGroupPermission{readonly}, expectedWrites: 0},
+		{name: "write lost to a concurrent change", lostWrites: 1, existing: []UserGroupPermission{readonly, analyst}, expectedWrites: 2},
+		{name: "every write lost", lostWrites: 10, existing: []UserGroupPermission{readonly, analyst}, expectedWrites: permissionWriteAttempts, expectError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fake := &fakeGroupPermissions{permissions: test.existing, lostWrites: test.lostWrites}
+			server := httptest.NewServer(fake)
+			defer server.Close()
+
+			diags := RemoveUserGroupPermission(context.Background(), client.NewClient(server.URL, "token", 1), analyst)
+
+			if diags.HasError() != test.expectError {
+				t.Fatalf("expected error to be %v, got %v", test.expectError, diags)
+			}
+
+			if !test.expectError && (findUserGroupPermission(&fake.permissions, analyst) != nil || findUserGroupPermission(&fake.permissions, readonly) == nil) {
+				t.Errorf("expected only the readonly permission to be left, got %v", fake.permissions)
+			}
+
+			if fake.writes != test.expectedWrites {
+				t.Errorf("expected %d writes, got %d", test.expectedWrites, fake.writes)
+			}
+		})
+	}
+}
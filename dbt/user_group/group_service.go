@@ -1,115 +1,183 @@
 package dbtusergroup
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
-	"net/http"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"terraform-provider-dbt/dbt/client"
+	utils "terraform-provider-dbt/dbt/utils"
 )
 
-func CreateUserGroup(groupInput *UserGroup, serviceToken string) (*UserGroup, diag.Diagnostics) {
-	url := fmt.Sprintf("https://cloud.getdbt.com/api/v3/accounts/%d/groups/", groupInput.AccountId)
+func CreateUserGroup(ctx context.Context, c *client.Client, groupInput *UserGroup) (*UserGroup, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/groups/", groupInput.AccountId)
 
-	return CreateOrUpdateUserGroup(groupInput, serviceToken, url, http.StatusCreated)
+	return CreateOrUpdateUserGroup(ctx, c, groupInput, path)
 }
 
-func UpdateUserGroup(groupInput *UserGroup, serviceToken string) (*UserGroup, diag.Diagnostics) {
-	url := fmt.Sprintf("https://cloud.getdbt.com/api/v3/accounts/%d/groups/%d/", groupInput.AccountId, groupInput.Id)
+func UpdateUserGroup(ctx context.Context, c *client.Client, groupInput *UserGroup) (*UserGroup, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/groups/%d/", groupInput.AccountId, groupInput.Id)
 
-	return CreateOrUpdateUserGroup(groupInput, serviceToken, url, http.StatusOK)
+	return CreateOrUpdateUserGroup(ctx, c, groupInput, path)
 }
 
-func CreateOrUpdateUserGroup(groupInput *UserGroup, serviceToken string, url string, expectedStatusCode int) (*UserGroup, diag.Diagnostics) {
-	var diags diag.Diagnostics
-
-	response, err := PostAsJson(groupInput, url, serviceToken)
+func CreateOrUpdateUserGroup(ctx context.Context, c *client.Client, groupInput *UserGroup, path string) (*UserGroup, diag.Diagnostics) {
+	group, err := client.Post[UserGroup](ctx, c, path, groupInput)
 	if err != nil {
-		return nil, diag.FromErr(err)
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in CreateOrUpdateUserGroup",
+			Detail:   err.Error(),
+		}}
 	}
 
-	data, _ := ioutil.ReadAll(response.Body)
-	defer response.Body.Close()
+	return group, nil
+}
+
+func ReadUserGroup(ctx context.Context, c *client.Client, groupInput *UserGroup) (*UserGroup, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/groups/%d/", groupInput.AccountId, groupInput.Id)
+
+	group, err := client.Get[UserGroup](ctx, c, path)
+	if client.IsNotFound(err) {
+		return nil, nil
+	}
 
-	if response.StatusCode != expectedStatusCode {
-		return nil, append(diags, diag.Diagnostic{
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  "UserGroup PostAsJson return http error code in CreateOrUpdateUserGroup",
-			Detail:   fmt.Sprintf("DBT returned StatusCode %d for (%s), message: %s", response.StatusCode, url, data),
-		})
+			Summary:  "Dbt returned an error in ReadUserGroup",
+			Detail:   err.Error(),
+		}}
+	}
+
+	if group.State == client.StateDeleted {
+		return nil, nil
+	}
+
+	// The group endpoint sometimes leaves out the permissions, the list endpoint includes them.
+	if group.UserGroupPermissions == nil {
+		groups, diags := ListUserGroups(ctx, c, groupInput.AccountId)
+		if diags != nil {
+			return nil, diags
+		}
+
+		for _, listedGroup := range *groups {
+			if listedGroup.Id == group.Id {
+				group.UserGroupPermissions = listedGroup.UserGroupPermissions
+			}
+		}
 	}
 
-	var groupResponse GetUserGroupResponse
-	err = json.Unmarshal(data, &groupResponse)
+	group.UserGroupPermissions = normalizeUserGroupPermissions(group.UserGroupPermissions)
+
+	return group, nil
+}
+
+func ListUserGroups(ctx context.Context, c *client.Client, accountId int) (*[]UserGroup, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/groups/", accountId)
+
+	groups, err := client.Get[[]UserGroup](ctx, c, path)
 	if err != nil {
-		return nil, append(diags, diag.Diagnostic{
+		return nil, diag.Diagnostics{diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  "Could not parse Dbt group response as json",
+			Summary:  "Dbt returned an error in ListUserGroups",
 			Detail:   err.Error(),
-		})
+		}}
 	}
 
-	return &groupResponse.Data, nil
+	return groups, nil
 }
 
-func ReadUserGroup(groupInput *UserGroup, serviceToken string) (*UserGroup, diag.Diagnostics) {
-	var diags diag.Diagnostics
+// FindUserGroupByName returns the group with the given name, or nil if there is no such group.
+func FindUserGroupByName(ctx context.Context, c *client.Client, accountId int, name string) (*UserGroup, diag.Diagnostics) {
+	groups, diags := ListUserGroups(ctx, c, accountId)
+	if diags != nil {
+		return nil, diags
+	}
 
-	url := fmt.Sprintf("https://cloud.getdbt.com/api/v3/accounts/%d/groups/%d/", groupInput.AccountId, groupInput.Id)
+	var matches []UserGroup
+	for _, group := range *groups {
+		if group.Name == name && group.State != client.StateDeleted {
+			matches = append(matches, group)
+		}
+	}
 
-	response, err := GetRequest(url, serviceToken)
-	if err != nil {
-		return nil, diag.FromErr(err)
+	if len(matches) > 1 {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Found more than one DBT group with the same name",
+			Detail:   fmt.Sprintf("There are %d groups named %q", len(matches), name),
+		}}
 	}
 
-	if response.StatusCode == 404 {
+	if len(matches) == 0 {
 		return nil, nil
 	}
 
-	data, _ := ioutil.ReadAll(response.Body)
-	defer response.Body.Close()
+	return &matches[0], nil
+}
 
-	if response.StatusCode != http.StatusOK {
-		return nil, append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "UserGroup GetRequest return http error code in ReadUserGroup",
-			Detail:   fmt.Errorf("DBT returned StatusCode %d for (%s), message: %s", response.StatusCode, url, data).Error(),
-		})
+// CheckAdoptedUserGroup fails if adopting the existing group would remove its permissions or sso mappings, since that
+// can lock users out of the account. groupPermissionsInput is nil if the permissions of the group are not managed.
+func CheckAdoptedUserGroup(existingGroup *UserGroup, groupInput *UserGroup, groupPermissionsInput *[]UserGroupPermission) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if groupPermissionsInput != nil {
+		var missing []string
+		for _, permission := range *normalizeUserGroupPermissions(existingGroup.UserGroupPermissions) {
+			if findUserGroupPermission(groupPermissionsInput, permission) == nil {
+				missing = append(missing, formatUserGroupPermission(permission))
+			}
+		}
+
+		if len(missing) > 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Adopting the group would revoke its permissions",
+				Detail:   fmt.Sprintf("The existing group %q has permissions that are not in group_permissions: %s. Add them to group_permissions, or set manage_permissions = false", existingGroup.Name, strings.Join(missing, ", ")),
+			})
+		}
 	}
 
-	var groupResponse GetUserGroupResponse
-	err = json.Unmarshal(data, &groupResponse)
-	if err != nil {
-		return nil, append(diags, diag.Diagnostic{
+	var missing []string
+	for _, ssoMappingGroup := range existingGroup.SsoMappingUserGroups {
+		if !utils.Contains(groupInput.SsoMappingUserGroups, ssoMappingGroup) {
+			missing = append(missing, ssoMappingGroup)
+		}
+	}
+
+	if len(missing) > 0 {
+		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  "Could not parse Dbt create group response as json",
-			Detail:   err.Error(),
+			Summary:  "Adopting the group would remove its sso mappings",
+			Detail:   fmt.Sprintf("The existing group %q is mapped to sso groups that are not in sso_mapping_groups: %s. Add them to sso_mapping_groups", existingGroup.Name, strings.Join(missing, ", ")),
 		})
 	}
 
-	return &groupResponse.Data, nil
+	return diags
 }
 
-func DeleteUserGroup(groupInput *UserGroup, serviceToken string) diag.Diagnostics {
-	url := fmt.Sprintf("https://cloud.getdbt.com/api/v3/accounts/%d/groups/%d/", groupInput.AccountId, groupInput.Id)
-	groupInput.State = 2
-
-	response, err := PostAsJson(groupInput, url, serviceToken)
-	if err != nil {
-		return diag.FromErr(err)
+func formatUserGroupPermission(permission UserGroupPermission) string {
+	if permission.AllProjects {
+		return fmt.Sprintf("%s for all projects", permission.PermissionSet)
 	}
 
-	if response.StatusCode != http.StatusOK {
-		data, _ := ioutil.ReadAll(response.Body)
-		defer response.Body.Close()
+	return fmt.Sprintf("%s for project %d", permission.PermissionSet, permission.ProjectId)
+}
 
-		return diag.Diagnostics{
-			diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "UserGroup PostAsJson return http error code in CreateUserGroup",
-				Detail:   fmt.Errorf("DBT returned StatusCode %d for (%s), message: %s", response.StatusCode, url, data).Error(),
-			}}
+func DeleteUserGroup(ctx context.Context, c *client.Client, groupInput *UserGroup) diag.Diagnostics {
+	path := fmt.Sprintf("/api/v3/accounts/%d/groups/%d/", groupInput.AccountId, groupInput.Id)
+	groupInput.State = client.StateDeleted
+
+	_, err := client.Post[UserGroup](ctx, c, path, groupInput)
+	if err != nil {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in DeleteUserGroup",
+			Detail:   err.Error(),
+		}}
 	}
 
 	return nil
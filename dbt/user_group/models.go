@@ -10,18 +10,11 @@ type UserGroup struct {
 	UserGroupPermissions *[]UserGroupPermission `json:"group_permissions,omitempty"`
 }
 
-type GetUserGroupResponse struct {
-	Data UserGroup `json:"data"`
-}
-
 type UserGroupPermission struct {
 	UserGroupId   int    `json:"group_id"`
 	AccountId     int    `json:"account_id"`
 	PermissionSet string `json:"permission_set"`
 	ProjectId     int    `json:"project_id,omitempty"`
 	AllProjects   bool   `json:"all_projects"`
-}
-
-type UserGroupPermissionsResponse struct {
-	Data []UserGroupPermission `json:"data"`
+	State         int    `json:"state,omitempty"`
 }
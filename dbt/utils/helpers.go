@@ -1,6 +1,12 @@
 package utils
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
 
 func Contains(stringList []string, value string) bool {
 	for _, val := range stringList {
@@ -40,3 +46,70 @@ func InterfaceToStringList(rawInterface interface{}) []string {
 	}
 	return stringList
 }
+
+func InterfaceListToStringList(rawList []interface{}) []string {
+	stringList := make([]string, len(rawList))
+	for i, v := range rawList {
+		stringList[i] = v.(string)
+	}
+	return stringList
+}
+
+func InterfaceListToIntList(rawList []interface{}) []int {
+	intList := make([]int, len(rawList))
+	for i, v := range rawList {
+		intList[i] = v.(int)
+	}
+	return intList
+}
+
+// IntToPointer returns nil for 0, which is the value terraform uses for an unset optional number.
+func IntToPointer(value int) *int {
+	if value == 0 {
+		return nil
+	}
+	return &value
+}
+
+func PointerToInt(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+// StringToPointer returns nil for "", which is the value terraform uses for an unset optional string.
+func StringToPointer(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func PointerToString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// FormatProjectScopedId builds the terraform id for objects that belong to a project, like environments.
+func FormatProjectScopedId(projectId int, id int) string {
+	return fmt.Sprintf("%d:%d", projectId, id)
+}
+
+// ParseProjectScopedId parses an id created by FormatProjectScopedId, returning the project id and the object id.
+func ParseProjectScopedId(id string) (int, int, error) {
+	parts := strings.Split(id, ":")
+
+	if len(parts) == 2 {
+		projectId, projectErr := strconv.Atoi(parts[0])
+		objectId, objectErr := strconv.Atoi(parts[1])
+
+		if projectErr == nil && objectErr == nil {
+			return projectId, objectId, nil
+		}
+	}
+
+	return 0, 0, fmt.Errorf("unexpected format of ID (%s), expected project_id:id", id)
+}
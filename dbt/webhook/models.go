@@ -0,0 +1,58 @@
+package dbtwebhook
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// EventTypes are the job run events a webhook can subscribe to.
+var EventTypes = []string{"job.run.started", "job.run.completed", "job.run.errored"}
+
+// Webhook is a webhook as it is sent to DBT.
+type Webhook struct {
+	Id          string   `json:"id,omitempty"`
+	AccountId   int      `json:"account_id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	ClientUrl   string   `json:"client_url"`
+	EventTypes  []string `json:"event_types"`
+	JobIds      []int    `json:"job_ids"`
+	Active      bool     `json:"active"`
+	HmacSecret  string   `json:"hmac_secret,omitempty"`
+}
+
+// webhookResponse is a webhook as DBT returns it. DBT takes the job ids as numbers, but returns them as strings.
+type webhookResponse struct {
+	Id          string   `json:"id"`
+	AccountId   int      `json:"account_id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	ClientUrl   string   `json:"client_url"`
+	EventTypes  []string `json:"event_types"`
+	JobIds      []string `json:"job_ids"`
+	Active      bool     `json:"active"`
+	HmacSecret  string   `json:"hmac_secret"`
+}
+
+func (w *webhookResponse) toWebhook() (*Webhook, error) {
+	jobIds := make([]int, len(w.JobIds))
+	for i, jobId := range w.JobIds {
+		id, err := strconv.Atoi(jobId)
+		if err != nil {
+			return nil, fmt.Errorf("webhook %s has an invalid job id %q: %w", w.Id, jobId, err)
+		}
+		jobIds[i] = id
+	}
+
+	return &Webhook{
+		Id:          w.Id,
+		AccountId:   w.AccountId,
+		Name:        w.Name,
+		Description: w.Description,
+		ClientUrl:   w.ClientUrl,
+		EventTypes:  w.EventTypes,
+		JobIds:      jobIds,
+		Active:      w.Active,
+		HmacSecret:  w.HmacSecret,
+	}, nil
+}
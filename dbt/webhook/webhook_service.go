@@ -0,0 +1,87 @@
+package dbtwebhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"terraform-provider-dbt/dbt/client"
+)
+
+func CreateWebhook(ctx context.Context, c *client.Client, webhookInput *Webhook) (*Webhook, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/webhooks/subscriptions", webhookInput.AccountId)
+
+	response, err := client.Post[webhookResponse](ctx, c, path, webhookInput)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in CreateWebhook",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return webhookFromResponse(response, "CreateWebhook")
+}
+
+func UpdateWebhook(ctx context.Context, c *client.Client, webhookInput *Webhook) (*Webhook, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/webhooks/subscription/%s", webhookInput.AccountId, webhookInput.Id)
+
+	response, err := client.Put[webhookResponse](ctx, c, path, webhookInput)
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in UpdateWebhook",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return webhookFromResponse(response, "UpdateWebhook")
+}
+
+func ReadWebhook(ctx context.Context, c *client.Client, accountId int, webhookId string) (*Webhook, diag.Diagnostics) {
+	path := fmt.Sprintf("/api/v3/accounts/%d/webhooks/subscription/%s", accountId, webhookId)
+
+	response, err := client.Get[webhookResponse](ctx, c, path)
+	if client.IsNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in ReadWebhook",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return webhookFromResponse(response, "ReadWebhook")
+}
+
+func DeleteWebhook(ctx context.Context, c *client.Client, accountId int, webhookId string) diag.Diagnostics {
+	path := fmt.Sprintf("/api/v3/accounts/%d/webhooks/subscription/%s", accountId, webhookId)
+
+	err := c.Delete(ctx, path)
+	if err != nil && !client.IsNotFound(err) {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an error in DeleteWebhook",
+			Detail:   err.Error(),
+		}}
+	}
+
+	return nil
+}
+
+func webhookFromResponse(response *webhookResponse, operation string) (*Webhook, diag.Diagnostics) {
+	webhook, err := response.toWebhook()
+	if err != nil {
+		return nil, diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Dbt returned an invalid webhook in " + operation,
+			Detail:   err.Error(),
+		}}
+	}
+
+	return webhook, nil
+}
@@ -0,0 +1,61 @@
+package dbtwebhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"terraform-provider-dbt/dbt/client"
+)
+
+func TestWebhookJobIds(t *testing.T) {
+	tests := []struct {
+		name           string
+		response       string
+		expectedJobIds []int
+		expectError    bool
+	}{
+		{name: "job ids as strings", response: `{"data":{"id":"wsu_1","job_ids":["12","34"]}}`, expectedJobIds: []int{12, 34}},
+		{name: "no job ids", response: `{"data":{"id":"wsu_1","job_ids":[]}}`, expectedJobIds: []int{}},
+		{name: "invalid job id", response: `{"data":{"id":"wsu_1","job_ids":["twelve"]}}`, expectError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var body map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					json.NewDecoder(r.Body).Decode(&body)
+				}
+				w.Write([]byte(test.response))
+			}))
+			defer server.Close()
+			c := client.NewClient(server.URL, "token", 1)
+
+			webhook, diags := CreateWebhook(context.Background(), c, &Webhook{AccountId: 1, JobIds: []int{12, 34}})
+			if diags.HasError() != test.expectError {
+				t.Fatalf("expected error to be %v, got %v", test.expectError, diags)
+			}
+
+			if !reflect.DeepEqual(body["job_ids"], []interface{}{12.0, 34.0}) {
+				t.Errorf("expected the job ids to be sent as numbers, got %v", body["job_ids"])
+			}
+
+			if !test.expectError && !reflect.DeepEqual(webhook.JobIds, test.expectedJobIds) {
+				t.Errorf("expected job ids %v, got %v", test.expectedJobIds, webhook.JobIds)
+			}
+
+			webhook, diags = ReadWebhook(context.Background(), c, 1, "wsu_1")
+			if diags.HasError() != test.expectError {
+				t.Fatalf("expected error to be %v, got %v", test.expectError, diags)
+			}
+
+			if !test.expectError && !reflect.DeepEqual(webhook.JobIds, test.expectedJobIds) {
+				t.Errorf("expected job ids %v, got %v", test.expectedJobIds, webhook.JobIds)
+			}
+		})
+	}
+}